@@ -0,0 +1,92 @@
+package encoding
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Transactions list compression codecs. A compressed transactions list payload is prefixed by its codec's
+// version tag, which is always smaller than 0xc0, the first byte of any RLP encoded list, so an uncompressed
+// transactions list can always be told apart from a compressed one by the payload itself.
+const (
+	TxListCodecNone byte = 0x00 // Only used in configurations, never used as a tag
+	TxListCodecZlib byte = 0x01
+)
+
+// MaxDecompressedTxListBytes is the maximum size of a decompressed transactions list,
+// to avoid decompression bombs.
+var MaxDecompressedTxListBytes = 1 << 24
+
+var errTxListTooLarge = errors.New("decompressed transactions list too large")
+
+// CompressTxListBytes compresses the given RLP encoded transactions list with the given codec,
+// and then prefixes the result with the codec's version tag.
+func CompressTxListBytes(txListBytes []byte, codec byte) ([]byte, error) {
+	switch codec {
+	case TxListCodecNone:
+		return txListBytes, nil
+	case TxListCodecZlib:
+		var buf bytes.Buffer
+		buf.WriteByte(TxListCodecZlib)
+
+		w, err := zlib.NewWriterLevel(&buf, zlib.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(txListBytes); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown transactions list codec: %d", codec)
+	}
+}
+
+// DecompressTxListBytes decompresses the given transactions list payload based on its version tag,
+// if the payload is not compressed, it will be returned directly.
+func DecompressTxListBytes(payload []byte) ([]byte, error) {
+	if len(payload) == 0 || payload[0] >= 0xc0 {
+		return payload, nil
+	}
+
+	switch payload[0] {
+	case TxListCodecZlib:
+		r, err := zlib.NewReader(bytes.NewReader(payload[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zlib reader: %w", err)
+		}
+		defer r.Close()
+
+		txListBytes, err := io.ReadAll(io.LimitReader(r, int64(MaxDecompressedTxListBytes)+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress transactions list: %w", err)
+		}
+
+		if len(txListBytes) > MaxDecompressedTxListBytes {
+			return nil, errTxListTooLarge
+		}
+
+		return txListBytes, nil
+	default:
+		return nil, fmt.Errorf("unknown transactions list version tag: %d", payload[0])
+	}
+}
+
+// ParseTxListCodec parses the given transactions list codec name.
+func ParseTxListCodec(name string) (byte, error) {
+	switch name {
+	case "", "none":
+		return TxListCodecNone, nil
+	case "zlib":
+		return TxListCodecZlib, nil
+	default:
+		return 0, fmt.Errorf("unknown transactions list codec: %s", name)
+	}
+}
@@ -0,0 +1,68 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+func TestCompressTxListBytes(t *testing.T) {
+	var txs types.Transactions
+	for i := 0; i < 64; i++ {
+		txs = append(txs, types.NewTx(&types.LegacyTx{Nonce: uint64(i), Gas: 21000, Data: make([]byte, 128)}))
+	}
+
+	txListBytes, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+
+	compressed, err := CompressTxListBytes(txListBytes, TxListCodecZlib)
+	require.Nil(t, err)
+	require.Equal(t, TxListCodecZlib, compressed[0])
+	require.Less(t, len(compressed), len(txListBytes))
+
+	decompressed, err := DecompressTxListBytes(compressed)
+	require.Nil(t, err)
+	require.Equal(t, txListBytes, decompressed)
+
+	// Uncompressed transactions list.
+	uncompressed, err := CompressTxListBytes(txListBytes, TxListCodecNone)
+	require.Nil(t, err)
+	decompressed, err = DecompressTxListBytes(uncompressed)
+	require.Nil(t, err)
+	require.Equal(t, txListBytes, decompressed)
+
+	_, err = CompressTxListBytes(txListBytes, 0xbf)
+	require.NotNil(t, err)
+}
+
+func TestDecompressTxListBytes(t *testing.T) {
+	decompressed, err := DecompressTxListBytes([]byte{})
+	require.Nil(t, err)
+	require.Empty(t, decompressed)
+
+	_, err = DecompressTxListBytes(append([]byte{TxListCodecZlib}, testutils.RandomBytes(32)...))
+	require.NotNil(t, err)
+
+	_, err = DecompressTxListBytes([]byte{0xbf, 0x00})
+	require.ErrorContains(t, err, "unknown transactions list version tag")
+
+	// Decompression bomb.
+	compressed, err := CompressTxListBytes(make([]byte, MaxDecompressedTxListBytes+1), TxListCodecZlib)
+	require.Nil(t, err)
+	_, err = DecompressTxListBytes(compressed)
+	require.ErrorIs(t, err, errTxListTooLarge)
+}
+
+func TestParseTxListCodec(t *testing.T) {
+	for name, codec := range map[string]byte{"": TxListCodecNone, "none": TxListCodecNone, "zlib": TxListCodecZlib} {
+		parsed, err := ParseTxListCodec(name)
+		require.Nil(t, err)
+		require.Equal(t, codec, parsed)
+	}
+
+	_, err := ParseTxListCodec("brotli")
+	require.NotNil(t, err)
+}
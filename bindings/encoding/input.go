@@ -260,8 +260,20 @@ func EncodeProveBlockInvalidInput(
 	return [][]byte{evidenceBytes, metaBytes, receiptBytes}, nil
 }
 
-// UnpackTxListBytes unpacks the input data of a TaikoL1.proposeBlock transaction, and returns the txList bytes.
+// UnpackTxListBytes unpacks the input data of a TaikoL1.proposeBlock transaction, and returns the txList bytes,
+// if the txList is compressed, the decompressed bytes will be returned.
 func UnpackTxListBytes(txData []byte) ([]byte, error) {
+	payload, err := UnpackRawTxListBytes(txData)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecompressTxListBytes(payload)
+}
+
+// UnpackRawTxListBytes unpacks the input data of a TaikoL1.proposeBlock transaction, and returns the txList
+// payload as it is in the calldata, which might be compressed.
+func UnpackRawTxListBytes(txData []byte) ([]byte, error) {
 	method, err := TaikoL1ABI.MethodById(txData)
 	if err != nil {
 		return nil, err
@@ -71,8 +71,53 @@ var (
 		Category: metricsCategory,
 		Value:    6060,
 	}
+	// Transactions lists
+	CompressedTxLists = &cli.BoolFlag{
+		Name: "txList.compressed",
+		Usage: "Accept the version-tagged compressed transactions lists, *MUST* be consistent among all " +
+			"clients, and only be enabled once TaikoL1's LibInvalidTxList.sol accepts them",
+		Value:    false,
+		Category: commonCategory,
+	}
+)
+
+// L1 transaction fees, only used by the softwares sending L1 transactions.
+var (
+	FeeBaseFeeMultiplier = &cli.Float64Flag{
+		Name:     "fee.baseFeeMultiplier",
+		Usage:    "Multiplier of the latest base fee in the gas fee cap of the sent L1 transactions, at least 1",
+		Value:    2,
+		Category: commonCategory,
+	}
+	FeeTipPercentile = &cli.Float64Flag{
+		Name: "fee.tipPercentile",
+		Usage: "Use the median of this percentile of the recent L1 blocks priority fees (eth_feeHistory) as the " +
+			"gas tip cap, 0 to use eth_maxPriorityFeePerGas",
+		Value:    0,
+		Category: commonCategory,
+	}
+	FeeMaxGasFeeCap = &cli.StringFlag{
+		Name: "fee.maxGasFeeCap",
+		Usage: "Hard limit of the gas fee cap (or the gas price in legacy mode) of the sent L1 transactions, " +
+			"empty to disable (in wei)",
+		Value:    "1000000000000",
+		Category: commonCategory,
+	}
+	FeeLegacy = &cli.BoolFlag{
+		Name:     "fee.legacy",
+		Usage:    "Send legacy gas price L1 transactions, for the L1s without London",
+		Category: commonCategory,
+	}
 )
 
+// All L1 transaction fees flags.
+var FeeFlags = []cli.Flag{
+	FeeBaseFeeMultiplier,
+	FeeTipPercentile,
+	FeeMaxGasFeeCap,
+	FeeLegacy,
+}
+
 // All common flags.
 var CommonFlags = []cli.Flag{
 	// Required
@@ -86,6 +131,7 @@ var CommonFlags = []cli.Flag{
 	MetricsEnabled,
 	MetricsAddr,
 	MetricsPort,
+	CompressedTxLists,
 }
 
 // MergeFlags merges the given flag slices.
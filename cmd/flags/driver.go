@@ -12,17 +12,41 @@ var (
 		Required: true,
 		Category: driverCategory,
 	}
+	JWTSecret = cli.StringFlag{
+		Name:     "jwtSecret",
+		Usage:    "Path to a JWT secret to use for authenticated RPC endpoints",
+		Required: true,
+		Category: driverCategory,
+	}
+)
+
+// Signer flags used by driver, exactly one of the private key, the keystore file
+// and the remote signer should be set.
+var (
 	ThrowawayBlocksBuilderPrivKey = cli.StringFlag{
 		Name: "l2.throwawayBlockBuilderPrivKey",
 		Usage: "Private key of the L2 throwaway blocks builder," +
 			"who will be the suggested fee recipient of L2 throwaway blocks",
-		Required: true,
 		Category: driverCategory,
 	}
-	JWTSecret = cli.StringFlag{
-		Name:     "jwtSecret",
-		Usage:    "Path to a JWT secret to use for authenticated RPC endpoints",
-		Required: true,
+	ThrowawayBlocksBuilderKeystore = cli.StringFlag{
+		Name:     "l2.throwawayBlockBuilderKeystore",
+		Usage:    "Path of an encrypted keystore file of the L2 throwaway blocks builder account",
+		Category: driverCategory,
+	}
+	ThrowawayBlocksBuilderKeystorePassword = cli.StringFlag{
+		Name:     "l2.throwawayBlockBuilderKeystorePassword",
+		Usage:    "Path of a file containing the password of the L2 throwaway blocks builder keystore file",
+		Category: driverCategory,
+	}
+	ThrowawayBlocksBuilderRemoteSigner = cli.StringFlag{
+		Name:     "l2.throwawayBlockBuilderRemoteSigner",
+		Usage:    "RPC endpoint of a remote signer (Clef or web3signer) holding the L2 throwaway blocks builder account",
+		Category: driverCategory,
+	}
+	ThrowawayBlocksBuilderAddress = cli.StringFlag{
+		Name:     "l2.throwawayBlockBuilderAddress",
+		Usage:    "Address of the L2 throwaway blocks builder account held by the remote signer",
 		Category: driverCategory,
 	}
 )
@@ -41,6 +65,10 @@ var (
 var DriverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	&L2NodeEngineEndpoint,
 	&ThrowawayBlocksBuilderPrivKey,
+	&ThrowawayBlocksBuilderKeystore,
+	&ThrowawayBlocksBuilderKeystorePassword,
+	&ThrowawayBlocksBuilderRemoteSigner,
+	&ThrowawayBlocksBuilderAddress,
 	&JWTSecret,
 	&P2PSyncVerifiedBlocks,
 })
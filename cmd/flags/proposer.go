@@ -8,15 +8,10 @@ import (
 
 // Required flags used by proposer.
 var (
-	L1ProposerPrivKey = cli.StringFlag{
-		Name:     "l1.proposerPrivKey",
-		Usage:    "Private key of the L1 proposer, who will send TaikoL1.proposeBlock transactions",
-		Required: true,
-		Category: proposerCategory,
-	}
 	L2SuggestedFeeRecipient = cli.StringFlag{
-		Name:     "l2.suggestedFeeRecipient",
-		Usage:    "Address of the proposed block's suggested fee recipient",
+		Name: "l2.suggestedFeeRecipient",
+		Usage: "Address of the proposed block's suggested fee recipient, multiple recipients are separated " +
+			"by commas, each can be followed by a weight used by the weighted rotation, e.g. 0x1234...:3",
 		Required: true,
 		Category: proposerCategory,
 	}
@@ -28,45 +23,322 @@ var (
 	}
 )
 
+// Signer flags used by proposer, exactly one of the private key, the keystore file
+// and the remote signer should be set.
+var (
+	L1ProposerPrivKey = cli.StringFlag{
+		Name:     "l1.proposerPrivKey",
+		Usage:    "Private key of the L1 proposer, who will send TaikoL1.proposeBlock transactions",
+		Category: proposerCategory,
+	}
+	L1ProposerKeystore = cli.StringFlag{
+		Name:     "l1.proposerKeystore",
+		Usage:    "Path of an encrypted keystore file of the L1 proposer account",
+		Category: proposerCategory,
+	}
+	L1ProposerKeystorePassword = cli.StringFlag{
+		Name:     "l1.proposerKeystorePassword",
+		Usage:    "Path of a file containing the password of the L1 proposer keystore file",
+		Category: proposerCategory,
+	}
+	L1ProposerRemoteSigner = cli.StringFlag{
+		Name:     "l1.proposerRemoteSigner",
+		Usage:    "RPC endpoint of a remote signer (Clef or web3signer) holding the L1 proposer account",
+		Category: proposerCategory,
+	}
+	L1ProposerAddress = cli.StringFlag{
+		Name:     "l1.proposerAddress",
+		Usage:    "Address of the L1 proposer account held by the remote signer",
+		Category: proposerCategory,
+	}
+)
+
 // Optional flags used by proposer.
 var (
 	CommitSlot = cli.Uint64Flag{
 		Name:     "commitSlot",
-		Usage:    "The commit slot will be used by proposer, by default, a random number will be used",
+		Usage:    "The first commit slot will be used by proposer, by default, a random number will be used",
 		Value:    rand.Uint64(),
 		Category: proposerCategory,
 	}
+	CommitSlotExpiry = cli.Uint64Flag{
+		Name:     "commitSlot.expiry",
+		Usage:    "Number of L1 blocks after which an outstanding commitment in a commit slot is considered expired",
+		Value:    1024,
+		Category: proposerCategory,
+	}
 	ShufflePoolContent = cli.BoolFlag{
-		Name:     "shufflePoolContent",
-		Usage:    "Perform a weighted shuffle when building the transactions list to propose",
+		Name: "shufflePoolContent",
+		Usage: "Perform a weighted shuffle when building the transactions list to propose, " +
+			"ignored if a txOrderingStrategy other than sender is set",
 		Value:    false,
 		Category: proposerCategory,
 	}
+	FeeRecipientRotation = cli.StringFlag{
+		Name: "l2.feeRecipientRotation",
+		Usage: "Rotation rule of multiple L2 suggested fee recipients, options: roundRobin (one by one for each " +
+			"block), epoch (one by one for each proposing epoch), weighted (blocks split by the weights)",
+		Value:    "roundRobin",
+		Category: proposerCategory,
+	}
+	TxOrderingStrategy = cli.StringFlag{
+		Name: "txOrderingStrategy",
+		Usage: "Order in which the pending transactions are filled into the transactions lists to propose, " +
+			"options: sender (sender by sender, sorted by nonce), maxTip (max effective tip first), " +
+			"fifo (first seen first), weightedRandom (gas price weighted random)",
+		Value:    "sender",
+		Category: proposerCategory,
+	}
+	TxSource = cli.StringFlag{
+		Name: "txSource",
+		Usage: "Source of the L2 pending transactions to propose, options: polling (fetch the whole transaction " +
+			"pool content every epoch), streaming (keep a local pending set up to date through subscriptions)",
+		Value:    "polling",
+		Category: proposerCategory,
+	}
+	TxListCompression = cli.StringFlag{
+		Name:     "txList.compression",
+		Usage:    "Compression codec of the proposed transactions lists, options: none, zlib",
+		Value:    "none",
+		Category: proposerCategory,
+	}
+	SenderListsFile = cli.StringFlag{
+		Name: "senderListsFile",
+		Usage: "Path of a JSON file containing priorityAccounts and priorityContracts, whose pending transactions " +
+			"are always proposed first, and deniedSenders, whose transactions are never proposed, " +
+			"the file is reloaded once modified",
+		Category: proposerCategory,
+	}
+	PrecheckTxLists = cli.BoolFlag{
+		Name: "txList.precheck",
+		Usage: "Check the pending transactions' nonces and the senders' balances against the L2 chain head " +
+			"state before proposing, and drop the ones with stale or gapped nonces or insufficient balances, " +
+			"the transactions are not executed",
+		Value:    false,
+		Category: proposerCategory,
+	}
+	BlockTargetGas = cli.Uint64Flag{
+		Name:     "block.targetGas",
+		Usage:    "Target gas limit of each proposed transactions list, at most the protocol maxGasPerBlock, 0 to use it",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	BlockMaxTxs = cli.Uint64Flag{
+		Name:     "block.maxTxs",
+		Usage:    "Maximum number of transactions in each proposed transactions list, at most the protocol maxTxPerBlock",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	BlockMaxBytes = cli.Uint64Flag{
+		Name:     "block.maxBytes",
+		Usage:    "Maximum size of each proposed transactions list, at most the protocol maxTxBytesPerBlock",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	BlockMinFillPercentage = cli.Uint64Flag{
+		Name: "block.minFillPercentage",
+		Usage: "Wait for more pending transactions if the last transactions list's gas limit is below this " +
+			"percentage of the target gas, 0 to disable",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	BlockMaxDeferredEpochs = cli.Uint64Flag{
+		Name: "block.maxDeferredEpochs",
+		Usage: "Propose an underfilled transactions list anyway after deferring it these many epochs in a row, " +
+			"0 means no limit",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	TriggerGasThreshold = cli.Uint64Flag{
+		Name: "trigger.gasThreshold",
+		Usage: "Propose early once the gas limit of the new pending transactions reaches this threshold, " +
+			"watched through the L2 node's new pending transactions subscription, 0 to disable",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	TriggerBytesThreshold = cli.Uint64Flag{
+		Name: "trigger.bytesThreshold",
+		Usage: "Propose early once the size of the new pending transactions reaches this threshold, " +
+			"watched through the L2 node's new pending transactions subscription, 0 to disable",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	TriggerMinGas = cli.Uint64Flag{
+		Name:     "trigger.minGas",
+		Usage:    "Skip the proposing interval ticks whose pending transactions' gas limit is below this value",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	TriggerMaxSkippedTicks = cli.Uint64Flag{
+		Name:     "trigger.maxSkippedTicks",
+		Usage:    "Propose anyway after skipping these many proposing interval ticks in a row, 0 means no limit",
+		Value:    10,
+		Category: proposerCategory,
+	}
+	CheckProfitability = cli.BoolFlag{
+		Name:     "profitability.check",
+		Usage:    "Skip the proposing epochs whose L2 tips can't cover the estimated L1 cost",
+		Value:    false,
+		Category: proposerCategory,
+	}
+	MinProfitMargin = cli.Float64Flag{
+		Name:     "profitability.minMargin",
+		Usage:    "Minimum margin of the L2 tips over the estimated L1 cost, e.g. 0.1 means 10%",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	MaxSkippedEpochs = cli.Uint64Flag{
+		Name:     "profitability.maxSkippedEpochs",
+		Usage:    "Propose anyway after skipping these many unprofitable epochs in a row, 0 means no limit",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	L2GasUsedDiscount = cli.Float64Flag{
+		Name: "profitability.l2GasDiscount",
+		Usage: "Discount of the L2 transactions' gas limits to estimate their gas used and collected tips, " +
+			"e.g. 0.2 means the gas used is 80% of the gas limit",
+		Value:    0.2,
+		Category: proposerCategory,
+	}
+	CommitBlockExecutionGas = cli.Uint64Flag{
+		Name: "profitability.commitBlockGas",
+		Usage: "Estimated execution gas used by a TaikoL1.commitBlock transaction, excluding the intrinsic " +
+			"and calldata gas",
+		Value:    50000,
+		Category: proposerCategory,
+	}
+	ProposeBlockExecutionGas = cli.Uint64Flag{
+		Name: "profitability.proposeBlockGas",
+		Usage: "Estimated execution gas used by a TaikoL1.proposeBlock transaction, excluding the intrinsic " +
+			"and calldata gas",
+		Value:    250000,
+		Category: proposerCategory,
+	}
+	ReorgConfirmations = cli.Uint64Flag{
+		Name: "reorg.confirmations",
+		Usage: "Track the propose transactions until they have these many L1 confirmations, and commit " +
+			"and propose the transactions lists again if their transactions are reorged out, 0 to disable",
+		Value:    12,
+		Category: proposerCategory,
+	}
+	BalanceWarnThreshold = cli.StringFlag{
+		Name:     "balance.warnThreshold",
+		Usage:    "Warn if the L1 proposer balance is below this value (in wei), 0 to disable",
+		Value:    "0",
+		Category: proposerCategory,
+	}
+	BalanceWarnEpochs = cli.Uint64Flag{
+		Name: "balance.warnEpochs",
+		Usage: "Warn if the L1 proposer balance can only fund fewer proposing epochs than this value, " +
+			"estimated by the average balance spent per epoch, 0 to disable",
+		Value:    0,
+		Category: proposerCategory,
+	}
+	PauseOnLowBalance = cli.BoolFlag{
+		Name:     "balance.pauseOnLow",
+		Usage:    "Pause proposing while the L1 proposer balance is below the warning thresholds",
+		Value:    false,
+		Category: proposerCategory,
+	}
+	DryRun = cli.BoolFlag{
+		Name:     "dryRun",
+		Usage:    "Only simulate the proposals and report the estimated cost, without sending any L1 transaction",
+		Value:    false,
+		Category: proposerCategory,
+	}
+	AdminRPCAddr = cli.StringFlag{
+		Name:     "admin.addr",
+		Usage:    "Listening address of the authenticated admin RPC server for runtime control, disabled if empty",
+		Category: proposerCategory,
+	}
+	AdminJWTSecret = cli.StringFlag{
+		Name:     "admin.jwtSecret",
+		Usage:    "Path to a JWT secret used to authenticate the admin RPC requests",
+		Category: proposerCategory,
+	}
+	TxResubmissionTimeout = cli.StringFlag{
+		Name:     "tx.resubmissionTimeout",
+		Usage:    "Re-broadcast the L1 transactions with bumped fees if they are not mined within this duration",
+		Value:    "3m",
+		Category: proposerCategory,
+	}
+	TxFeeBumpPercentage = cli.Uint64Flag{
+		Name:     "tx.feeBumpPercentage",
+		Usage:    "Percentage of the fees bump when re-broadcasting a stuck L1 transaction, at least 10",
+		Value:    12,
+		Category: proposerCategory,
+	}
 )
 
 // Special flags for testing.
 var (
+	FaultInjectionConfig = cli.StringFlag{
+		Name: "faultInjection.config",
+		Usage: "Special flag for testnet testing, path of a JSON file containing the scenarios of the faulty " +
+			"transactions lists to propose, each scenario has a reason, e.g. TX_INVALID_SIG, and a schedule " +
+			"of interval, start and count in proposing epochs",
+		Hidden:   true,
+		Category: proposerCategory,
+	}
 	ProduceInvalidBlocks = cli.BoolFlag{
-		Name:     "produceInvalidBlocks",
-		Usage:    "Special flag for testnet testing, if activated, the proposer will start producing bad blocks",
+		Name: "produceInvalidBlocks",
+		Usage: "Deprecated, use faultInjection.config instead. Special flag for testnet testing, if activated, " +
+			"the proposer will start producing bad blocks",
 		Hidden:   true,
 		Category: proposerCategory,
 	}
 	ProduceInvalidBlocksInterval = cli.Uint64Flag{
-		Name:     "produceInvalidBlocksInterval",
-		Usage:    "Special flag for testnet testing, if activated, bad blocks will be produced every N valid blocks",
+		Name: "produceInvalidBlocksInterval",
+		Usage: "Deprecated, use faultInjection.config instead. Special flag for testnet testing, if activated, " +
+			"bad blocks will be produced every N valid blocks",
 		Hidden:   true,
 		Category: proposerCategory,
 	}
 )
 
 // All proposer flags.
-var ProposerFlags = MergeFlags(CommonFlags, []cli.Flag{
+var ProposerFlags = MergeFlags(CommonFlags, FeeFlags, []cli.Flag{
 	&L1ProposerPrivKey,
+	&L1ProposerKeystore,
+	&L1ProposerKeystorePassword,
+	&L1ProposerRemoteSigner,
+	&L1ProposerAddress,
 	&L2SuggestedFeeRecipient,
 	&ProposeInterval,
+	&FaultInjectionConfig,
 	&ProduceInvalidBlocks,
 	&ProduceInvalidBlocksInterval,
 	&ShufflePoolContent,
+	&FeeRecipientRotation,
+	&TxOrderingStrategy,
+	&TxSource,
+	&SenderListsFile,
+	&TxListCompression,
+	&PrecheckTxLists,
+	&BlockTargetGas,
+	&BlockMaxTxs,
+	&BlockMaxBytes,
+	&BlockMinFillPercentage,
+	&BlockMaxDeferredEpochs,
+	&TriggerGasThreshold,
+	&TriggerBytesThreshold,
+	&TriggerMinGas,
+	&TriggerMaxSkippedTicks,
+	&CheckProfitability,
+	&MinProfitMargin,
+	&MaxSkippedEpochs,
+	&L2GasUsedDiscount,
+	&CommitBlockExecutionGas,
+	&ProposeBlockExecutionGas,
+	&ReorgConfirmations,
+	&BalanceWarnThreshold,
+	&BalanceWarnEpochs,
+	&PauseOnLowBalance,
+	&DryRun,
+	&AdminRPCAddr,
+	&AdminJWTSecret,
+	&TxResubmissionTimeout,
+	&TxFeeBumpPercentage,
 	&CommitSlot,
+	&CommitSlotExpiry,
 })
@@ -8,7 +8,7 @@ import (
 var (
 	ZkEvmRpcdEndpoint = cli.StringFlag{
 		Name:     "zkevmRpcdEndpoint",
-		Usage:    "RPC endpoint of a ZKEVM RPCD service",
+		Usage:    "RPC endpoints of ZKEVM RPCD services, separated by commas, the proofs are balanced among them",
 		Required: true,
 		Category: proverCategory,
 	}
@@ -18,11 +18,70 @@ var (
 		Required: true,
 		Category: proverCategory,
 	}
+)
+
+// Signer flags used by prover, exactly one of the private key, the keystore file
+// and the remote signer should be set.
+var (
 	L1ProverPrivKey = cli.StringFlag{
 		Name: "l1.proverPrivKey",
 		Usage: "Private key of L1 prover, " +
 			"who will send TaikoL1.proveBlock / TaikoL1.proveBlockInvalid transactions",
-		Required: true,
+		Category: proverCategory,
+	}
+	L1ProverKeystore = cli.StringFlag{
+		Name:     "l1.proverKeystore",
+		Usage:    "Path of an encrypted keystore file of the L1 prover account",
+		Category: proverCategory,
+	}
+	L1ProverKeystorePassword = cli.StringFlag{
+		Name:     "l1.proverKeystorePassword",
+		Usage:    "Path of a file containing the password of the L1 prover keystore file",
+		Category: proverCategory,
+	}
+	L1ProverRemoteSigner = cli.StringFlag{
+		Name:     "l1.proverRemoteSigner",
+		Usage:    "RPC endpoint of a remote signer (Clef or web3signer) holding the L1 prover account",
+		Category: proverCategory,
+	}
+	L1ProverAddress = cli.StringFlag{
+		Name:     "l1.proverAddress",
+		Usage:    "Address of the L1 prover account held by the remote signer",
+		Category: proverCategory,
+	}
+)
+
+// Optional flags used by prover.
+var (
+	JobStorePath = cli.StringFlag{
+		Name: "jobStore",
+		Usage: "Path of a directory to persist the proving jobs in, so the requested proofs can be resumed " +
+			"after restarts, the jobs are only kept in memory if not set",
+		Category: proverCategory,
+	}
+	ProofConcurrency = cli.Uint64Flag{
+		Name:     "proof.concurrency",
+		Usage:    "Maximum number of proofs being generated at the same time",
+		Value:    1,
+		Category: proverCategory,
+	}
+	SelectionCriteria = cli.StringFlag{
+		Name: "selection.criteria",
+		Usage: "Criteria to rank the unproven blocks by: \"age\" (oldest first), \"reward\" (fewest existing " +
+			"proofs first, as a proxy of the highest expected reward) or \"ownProposers\" (blocks proposed by " +
+			"selection.ownProposers first)",
+		Value:    "age",
+		Category: proverCategory,
+	}
+	SelectionOwnProposers = cli.StringFlag{
+		Name:     "selection.ownProposers",
+		Usage:    "Addresses of our own L1 proposers, separated by commas, only used by the ownProposers criteria",
+		Category: proverCategory,
+	}
+	SelectionMaxProofs = cli.Uint64Flag{
+		Name:     "selection.maxProofs",
+		Usage:    "Skip the blocks already having this many proofs, 0 to use the protocol maxProofsPerForkChoice",
+		Value:    0,
 		Category: proverCategory,
 	}
 )
@@ -38,9 +97,18 @@ var (
 )
 
 // All prover flags.
-var ProverFlags = MergeFlags(CommonFlags, []cli.Flag{
+var ProverFlags = MergeFlags(CommonFlags, FeeFlags, []cli.Flag{
 	&ZkEvmRpcdEndpoint,
 	&ZkEvmRpcdParamsPath,
 	&L1ProverPrivKey,
+	&L1ProverKeystore,
+	&L1ProverKeystorePassword,
+	&L1ProverRemoteSigner,
+	&L1ProverAddress,
+	&JobStorePath,
+	&ProofConcurrency,
+	&SelectionCriteria,
+	&SelectionOwnProposers,
+	&SelectionMaxProofs,
 	&Dummy,
 })
@@ -12,13 +12,13 @@ import (
 	"github.com/ethereum/go-ethereum/core/beacon"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/metrics"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
 )
 
@@ -69,7 +69,7 @@ func (s *L2ChainSyncer) onBlockProposed(
 		return fmt.Errorf("failed to fetch original TaikoL1.proposeBlock transaction: %w", err)
 	}
 
-	txListBytes, err := encoding.UnpackTxListBytes(tx.Data())
+	txListBytes, err := encoding.UnpackRawTxListBytes(tx.Data())
 	if err != nil {
 		log.Info(
 			"Skip the throw away block",
@@ -109,12 +109,18 @@ func (s *L2ChainSyncer) onBlockProposed(
 		payloadError error
 	)
 	if hint == txListValidator.HintOK {
+		// The transactions list has been validated, so it can always be decompressed here.
+		decompressed, err := encoding.DecompressTxListBytes(txListBytes)
+		if err != nil {
+			return fmt.Errorf("failed to decompress transactions list: %w", err)
+		}
+
 		payloadData, rpcError, payloadError = s.insertNewHead(
 			ctx,
 			event,
 			parent,
 			s.state.getHeadBlockID(),
-			txListBytes,
+			decompressed,
 			l1Origin,
 		)
 	} else {
@@ -345,21 +351,11 @@ func (s *L2ChainSyncer) createExecutionPayloads(
 }
 
 // getInvalidateBlockTxOpts signs the transaction with a the
-// throwaway blocks builder private key.
+// throwaway blocks builder signer.
 func (s *L2ChainSyncer) getInvalidateBlockTxOpts(ctx context.Context, height *big.Int) (*bind.TransactOpts, error) {
-	opts, err := bind.NewKeyedTransactorWithChainID(
-		s.throwawayBlocksBuilderPrivKey,
-		s.rpc.L2ChainID,
-	)
-	if err != nil {
-		return nil, err
-	}
+	opts := signer.NewTransactOpts(ctx, s.throwawayBlocksBuilderSigner, s.rpc.L2ChainID)
 
-	nonce, err := s.rpc.L2AccountNonce(
-		ctx,
-		crypto.PubkeyToAddress(s.throwawayBlocksBuilderPrivKey.PublicKey),
-		height,
-	)
+	nonce, err := s.rpc.L2AccountNonce(ctx, s.throwawayBlocksBuilderSigner.Address(), height)
 	if err != nil {
 		return nil, err
 	}
@@ -2,7 +2,6 @@ package driver
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
@@ -12,15 +11,16 @@ import (
 	"github.com/taikoxyz/taiko-client/metrics"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
 )
 
 type L2ChainSyncer struct {
-	ctx                           context.Context
-	state                         *State                           // Driver's state
-	rpc                           *rpc.Client                      // L1/L2 RPC clients
-	throwawayBlocksBuilderPrivKey *ecdsa.PrivateKey                // Private key of L2 throwaway blocks builder
-	txListValidator               *txListValidator.TxListValidator // Transactions list validator
+	ctx                          context.Context
+	state                        *State                           // Driver's state
+	rpc                          *rpc.Client                      // L1/L2 RPC clients
+	throwawayBlocksBuilderSigner signer.Signer                    // Signer of L2 throwaway blocks builder
+	txListValidator              *txListValidator.TxListValidator // Transactions list validator
 	// Try P2P beacon-sync if current node is behind of  the protocol's latest verified block head
 	p2pSyncVerifiedBlocks       bool
 	lastSyncedVerifiedBlockHash common.Hash
@@ -33,20 +33,22 @@ func NewL2ChainSyncer(
 	ctx context.Context,
 	rpc *rpc.Client,
 	state *State,
-	throwawayBlocksBuilderPrivKey *ecdsa.PrivateKey,
+	throwawayBlocksBuilderSigner signer.Signer,
 	p2pSyncVerifiedBlocks bool,
+	compressedTxLists bool,
 ) (*L2ChainSyncer, error) {
 	return &L2ChainSyncer{
-		ctx:                           ctx,
-		rpc:                           rpc,
-		state:                         state,
-		throwawayBlocksBuilderPrivKey: throwawayBlocksBuilderPrivKey,
+		ctx:                          ctx,
+		rpc:                          rpc,
+		state:                        state,
+		throwawayBlocksBuilderSigner: throwawayBlocksBuilderSigner,
 		txListValidator: txListValidator.NewTxListValidator(
 			state.maxBlocksGasLimit.Uint64(),
 			state.maxBlockNumTxs.Uint64(),
 			state.maxTxlistBytes.Uint64(),
 			state.minTxGasLimit.Uint64(),
 			rpc.L2ChainID,
+			compressedTxLists,
 		),
 		p2pSyncVerifiedBlocks: p2pSyncVerifiedBlocks,
 	}, nil
@@ -1,26 +1,26 @@
 package driver
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
 	"github.com/taikoxyz/taiko-client/pkg/jwt"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	"github.com/urfave/cli/v2"
 )
 
 // Config contains the configurations to initialize a Taiko driver.
 type Config struct {
-	L1Endpoint                    string
-	L2Endpoint                    string
-	L2EngineEndpoint              string
-	TaikoL1Address                common.Address
-	TaikoL2Address                common.Address
-	ThrowawayBlocksBuilderPrivKey *ecdsa.PrivateKey
-	JwtSecret                     string
-	P2PSyncVerifiedBlocks         bool
+	L1Endpoint                   string
+	L2Endpoint                   string
+	L2EngineEndpoint             string
+	TaikoL1Address               common.Address
+	TaikoL2Address               common.Address
+	ThrowawayBlocksBuilderSigner signer.Signer
+	JwtSecret                    string
+	P2PSyncVerifiedBlocks        bool
+	CompressedTxLists            bool
 }
 
 // NewConfigFromCliContext creates a new config instance from
@@ -31,19 +31,26 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT secret file: %w", err)
 	}
 
-	throwawayBlocksBuilderPrivKey, err := crypto.HexToECDSA(c.String(flags.ThrowawayBlocksBuilderPrivKey.Name))
+	throwawayBlocksBuilderSigner, err := signer.New(c.Context, &signer.Config{
+		PrivKey:              c.String(flags.ThrowawayBlocksBuilderPrivKey.Name),
+		KeystorePath:         c.String(flags.ThrowawayBlocksBuilderKeystore.Name),
+		KeystorePasswordFile: c.String(flags.ThrowawayBlocksBuilderKeystorePassword.Name),
+		RemoteSignerEndpoint: c.String(flags.ThrowawayBlocksBuilderRemoteSigner.Name),
+		RemoteSignerAddress:  c.String(flags.ThrowawayBlocksBuilderAddress.Name),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid throwaway blocks builder private key: %w", err)
+		return nil, fmt.Errorf("invalid throwaway blocks builder signer: %w", err)
 	}
 
 	return &Config{
-		L1Endpoint:                    c.String(flags.L1NodeEndpoint.Name),
-		L2Endpoint:                    c.String(flags.L2NodeEndpoint.Name),
-		L2EngineEndpoint:              c.String(flags.L2NodeEngineEndpoint.Name),
-		TaikoL1Address:                common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
-		TaikoL2Address:                common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
-		ThrowawayBlocksBuilderPrivKey: throwawayBlocksBuilderPrivKey,
-		JwtSecret:                     string(jwtSecret),
-		P2PSyncVerifiedBlocks:         c.Bool(flags.P2PSyncVerifiedBlocks.Name),
+		L1Endpoint:                   c.String(flags.L1NodeEndpoint.Name),
+		L2Endpoint:                   c.String(flags.L2NodeEndpoint.Name),
+		L2EngineEndpoint:             c.String(flags.L2NodeEngineEndpoint.Name),
+		TaikoL1Address:               common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+		TaikoL2Address:               common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+		ThrowawayBlocksBuilderSigner: throwawayBlocksBuilderSigner,
+		JwtSecret:                    string(jwtSecret),
+		P2PSyncVerifiedBlocks:        c.Bool(flags.P2PSyncVerifiedBlocks.Name),
+		CompressedTxLists:            c.Bool(flags.CompressedTxLists.Name),
 	}, nil
 }
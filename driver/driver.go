@@ -9,7 +9,6 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
@@ -72,7 +71,7 @@ func InitFromConfig(ctx context.Context, d *Driver, cfg *Config) (err error) {
 		return err
 	}
 
-	balance, err := d.rpc.L2.BalanceAt(d.ctx, crypto.PubkeyToAddress(cfg.ThrowawayBlocksBuilderPrivKey.PublicKey), nil)
+	balance, err := d.rpc.L2.BalanceAt(d.ctx, cfg.ThrowawayBlocksBuilderSigner.Address(), nil)
 	if err != nil {
 		return fmt.Errorf("failed to get throwaway blocks builder balance: %w", err)
 	}
@@ -94,8 +93,9 @@ func InitFromConfig(ctx context.Context, d *Driver, cfg *Config) (err error) {
 		d.ctx,
 		d.rpc,
 		d.state,
-		cfg.ThrowawayBlocksBuilderPrivKey,
+		cfg.ThrowawayBlocksBuilderSigner,
 		cfg.P2PSyncVerifiedBlocks,
+		cfg.CompressedTxLists,
 	); err != nil {
 		return err
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/suite"
 	"github.com/taikoxyz/taiko-client/pkg/jwt"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	"github.com/taikoxyz/taiko-client/proposer"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
@@ -36,13 +37,13 @@ func (s *DriverTestSuite) SetupTest() {
 
 	d := new(Driver)
 	s.Nil(InitFromConfig(context.Background(), d, &Config{
-		L1Endpoint:                    os.Getenv("L1_NODE_ENDPOINT"),
-		L2Endpoint:                    os.Getenv("L2_NODE_ENDPOINT"),
-		L2EngineEndpoint:              os.Getenv("L2_NODE_ENGINE_ENDPOINT"),
-		TaikoL1Address:                common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
-		TaikoL2Address:                common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
-		ThrowawayBlocksBuilderPrivKey: throwawayBlocksBuilderPrivKey,
-		JwtSecret:                     string(jwtSecret),
+		L1Endpoint:                   os.Getenv("L1_NODE_ENDPOINT"),
+		L2Endpoint:                   os.Getenv("L2_NODE_ENDPOINT"),
+		L2EngineEndpoint:             os.Getenv("L2_NODE_ENGINE_ENDPOINT"),
+		TaikoL1Address:               common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		TaikoL2Address:               common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		ThrowawayBlocksBuilderSigner: signer.NewPrivateKeySigner(throwawayBlocksBuilderPrivKey),
+		JwtSecret:                    string(jwtSecret),
 	}))
 	s.d = d
 
@@ -53,13 +54,15 @@ func (s *DriverTestSuite) SetupTest() {
 	s.Nil(err)
 
 	s.Nil(proposer.InitFromConfig(context.Background(), p, (&proposer.Config{
-		L1Endpoint:              os.Getenv("L1_NODE_ENDPOINT"),
-		L2Endpoint:              os.Getenv("L2_NODE_ENDPOINT"),
-		TaikoL1Address:          common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
-		TaikoL2Address:          common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
-		L1ProposerPrivKey:       l1ProposerPrivKey,
-		L2SuggestedFeeRecipient: common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")),
-		ProposeInterval:         1024 * time.Hour, // No need to periodically propose transactions list in unit tests
+		L1Endpoint:       os.Getenv("L1_NODE_ENDPOINT"),
+		L2Endpoint:       os.Getenv("L2_NODE_ENDPOINT"),
+		TaikoL1Address:   common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		TaikoL2Address:   common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		L1ProposerSigner: signer.NewPrivateKeySigner(l1ProposerPrivKey),
+		L2SuggestedFeeRecipients: []*proposer.FeeRecipient{
+			{Address: common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")), Weight: 1},
+		},
+		ProposeInterval: 1024 * time.Hour, // No need to periodically propose transactions list in unit tests
 	})))
 	s.p = p
 	s.p.AfterCommitHook = s.MineL1Confirmations
@@ -27,6 +27,23 @@ var (
 	ProposerProposedTxListsCounter = metrics.NewRegisteredCounter("proposer/proposed/txLists", nil)
 	ProposerProposedTxsCounter     = metrics.NewRegisteredCounter("proposer/proposed/txs", nil)
 	ProposerInvalidTxsCounter      = metrics.NewRegisteredCounter("proposer/invalid/txs", nil)
+	ProposerPrecheckDropsCounter   = metrics.NewRegisteredCounter("proposer/precheck/dropped/txs", nil)
+	ProposerDroppedTxsCounter      = metrics.NewRegisteredCounter("proposer/dropped/txs", nil)
+	ProposerSkippedEpochsCounter   = metrics.NewRegisteredCounter("proposer/skipped/epochs", nil)
+	ProposerReorgedTxListsCounter  = metrics.NewRegisteredCounter("proposer/reorged/txLists", nil)
+	ProposerSkippedTicksCounter    = metrics.NewRegisteredCounter("proposer/skipped/ticks", nil)
+	ProposerDeferredTxListsCounter = metrics.NewRegisteredCounter("proposer/deferred/txLists", nil)
+	ProposerTriggeredEpochsCounter = metrics.NewRegisteredCounter("proposer/triggered/epochs", nil)
+	ProposerInFlightCommitsGauge   = metrics.NewRegisteredGauge("proposer/inFlight/commits", nil)
+	ProposerStreamedTxsGauge       = metrics.NewRegisteredGauge("proposer/streamed/pending/txs", nil)
+	ProposerBalanceGauge           = metrics.NewRegisteredGauge("proposer/balance/gwei", nil)
+	ProposerFundedEpochsGauge      = metrics.NewRegisteredGauge("proposer/funded/epochs", nil)
+	ProposerLowBalanceGauge        = metrics.NewRegisteredGauge("proposer/balance/low", nil)
+	ProposerDryRunTxListsGauge     = metrics.NewRegisteredGauge("proposer/dryRun/txLists", nil)
+	ProposerDryRunTxsGauge         = metrics.NewRegisteredGauge("proposer/dryRun/txs", nil)
+	ProposerDryRunBytesGauge       = metrics.NewRegisteredGauge("proposer/dryRun/bytes", nil)
+	ProposerDryRunGasGauge         = metrics.NewRegisteredGauge("proposer/dryRun/gas", nil)
+	ProposerDryRunL1CostGauge      = metrics.NewRegisteredGauge("proposer/dryRun/l1Cost/gwei", nil)
 
 	// Prover
 	ProverLatestVerifiedIDGauge       = metrics.NewRegisteredGauge("prover/lastVerified/id", nil)
@@ -39,7 +56,11 @@ var (
 	ProverSentProofCounter            = metrics.NewRegisteredCounter("prover/proof/all/sent", nil)
 	ProverSentValidProofCounter       = metrics.NewRegisteredCounter("prover/proof/valid/sent", nil)
 	ProverSentInvalidProofCounter     = metrics.NewRegisteredCounter("prover/proof/invalid/sent", nil)
+	ProverFailedProofCounter          = metrics.NewRegisteredCounter("prover/proof/all/failed", nil)
+	ProverInFlightProofGauge          = metrics.NewRegisteredGauge("prover/proof/all/inFlight", nil)
+	ProverHealthyProducerGauge        = metrics.NewRegisteredGauge("prover/producer/healthy", nil)
 	ProverReceivedProposedBlockGauge  = metrics.NewRegisteredGauge("prover/proposed/received", nil)
+	ProverSkippedProvenBlockCounter   = metrics.NewRegisteredCounter("prover/proposed/skipped", nil)
 )
 
 // Serve starts the metrics server on the given address, will be close when the given
@@ -0,0 +1,211 @@
+package fee_strategy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// DefaultBaseFeeMultiplier is the multiplier of the latest base fee used in the gas fee cap, if not configured,
+// which keeps the transaction includable after several consecutive full blocks.
+const DefaultBaseFeeMultiplier = 2.0
+
+// Number of the recent blocks to sample the priority fees from, when the tip percentile is configured.
+var feeHistoryBlocks uint64 = 20
+
+// Backend contains all node RPC methods used by FeeStrategy, *ethclient.Client implements
+// this interface.
+type Backend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FeeHistory(
+		ctx context.Context,
+		blockCount uint64,
+		lastBlock *big.Int,
+		rewardPercentiles []float64,
+	) (*ethereum.FeeHistory, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// Config contains all configurations of a FeeStrategy.
+type Config struct {
+	BaseFeeMultiplier float64  // Multiplier of the latest base fee in the gas fee cap, DefaultBaseFeeMultiplier if 0
+	TipPercentile     float64  // Percentile of the recent priority fees as the tip, 0 to use eth_maxPriorityFeePerGas
+	MaxGasFeeCap      *big.Int // Hard limit of the gas fee cap (or the gas price in legacy mode), nil or 0 to disable
+	Legacy            bool     // Use the legacy gas price, for the chains without London
+}
+
+// Validate checks whether the configurations are valid.
+func (c *Config) Validate() error {
+	if c.BaseFeeMultiplier != 0 && c.BaseFeeMultiplier < 1 {
+		return fmt.Errorf("base fee multiplier must be at least 1: %v", c.BaseFeeMultiplier)
+	}
+	if c.TipPercentile < 0 || c.TipPercentile > 100 {
+		return fmt.Errorf("tip percentile must be in [0, 100]: %v", c.TipPercentile)
+	}
+	if c.MaxGasFeeCap != nil && c.MaxGasFeeCap.Sign() < 0 {
+		return fmt.Errorf("max gas fee cap must not be negative: %s", c.MaxGasFeeCap)
+	}
+
+	return nil
+}
+
+// Fees contains the suggested fees of a transaction, GasPrice is only set in legacy mode,
+// otherwise GasTipCap and GasFeeCap are set.
+type Fees struct {
+	BaseFee   *big.Int // Latest base fee, nil in legacy mode
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	GasPrice  *big.Int
+}
+
+// EffectiveGasPrice returns the gas price which will be paid, if the transaction is included
+// with the latest base fee.
+func (f *Fees) EffectiveGasPrice() *big.Int {
+	if f.GasPrice != nil {
+		return new(big.Int).Set(f.GasPrice)
+	}
+
+	price := new(big.Int).Add(f.BaseFee, f.GasTipCap)
+	if price.Cmp(f.GasFeeCap) > 0 {
+		return new(big.Int).Set(f.GasFeeCap)
+	}
+
+	return price
+}
+
+// FeeStrategy suggests the fees of the transactions sent to a node, the EIP-1559 fee cap is
+// the latest base fee times the multiplier plus the tip, limited by a hard max fee cap.
+type FeeStrategy struct {
+	backend Backend
+	cfg     *Config
+}
+
+// New creates a new FeeStrategy instance, a nil config means all the default configurations.
+func New(backend Backend, cfg *Config) *FeeStrategy {
+	if cfg == nil {
+		cfg = new(Config)
+	}
+
+	return &FeeStrategy{backend: backend, cfg: cfg}
+}
+
+// SuggestFees suggests the fees of a new transaction, based on the latest chain head.
+// The legacy gas price is used if configured, or the chain head has no base fee.
+func (s *FeeStrategy) SuggestFees(ctx context.Context) (*Fees, error) {
+	if s.cfg.Legacy {
+		return s.suggestLegacyFees(ctx)
+	}
+
+	head, err := s.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain head: %w", err)
+	}
+
+	if head.BaseFee == nil {
+		return s.suggestLegacyFees(ctx)
+	}
+
+	gasTipCap, err := s.suggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	multiplier := s.cfg.BaseFeeMultiplier
+	if multiplier == 0 {
+		multiplier = DefaultBaseFeeMultiplier
+	}
+
+	gasFeeCap, _ := new(big.Float).Mul(new(big.Float).SetInt(head.BaseFee), big.NewFloat(multiplier)).Int(nil)
+	gasFeeCap.Add(gasFeeCap, gasTipCap)
+
+	if s.maxGasFeeCapEnabled() && gasFeeCap.Cmp(s.cfg.MaxGasFeeCap) > 0 {
+		if s.cfg.MaxGasFeeCap.Cmp(head.BaseFee) < 0 {
+			log.Warn(
+				"Max gas fee cap is below the latest base fee, transaction may not be included",
+				"maxGasFeeCap", s.cfg.MaxGasFeeCap,
+				"baseFee", head.BaseFee,
+			)
+		}
+		gasFeeCap = new(big.Int).Set(s.cfg.MaxGasFeeCap)
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+
+	return &Fees{BaseFee: head.BaseFee, GasTipCap: gasTipCap, GasFeeCap: gasFeeCap}, nil
+}
+
+// Apply sets the suggested fees to the given bind.TransactOpts instance.
+func (s *FeeStrategy) Apply(ctx context.Context, opts *bind.TransactOpts) error {
+	fees, err := s.SuggestFees(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts.GasPrice, opts.GasTipCap, opts.GasFeeCap = fees.GasPrice, fees.GasTipCap, fees.GasFeeCap
+
+	return nil
+}
+
+// suggestLegacyFees suggests the legacy gas price, limited by the max gas fee cap.
+func (s *FeeStrategy) suggestLegacyFees(ctx context.Context) (*Fees, error) {
+	gasPrice, err := s.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch suggested gas price: %w", err)
+	}
+
+	if s.maxGasFeeCapEnabled() && gasPrice.Cmp(s.cfg.MaxGasFeeCap) > 0 {
+		gasPrice = new(big.Int).Set(s.cfg.MaxGasFeeCap)
+	}
+
+	return &Fees{GasPrice: gasPrice}, nil
+}
+
+// suggestGasTipCap suggests the gas tip cap, which is the median of the configured percentile
+// of the recent blocks priority fees, or the `eth_maxPriorityFeePerGas` result. If the node
+// doesn't support `eth_maxPriorityFeePerGas`, rpc.FallbackGasTipCap will be used.
+func (s *FeeStrategy) suggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if s.cfg.TipPercentile != 0 {
+		history, err := s.backend.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{s.cfg.TipPercentile})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch fee history: %w", err)
+		}
+
+		var rewards []*big.Int
+		for _, reward := range history.Reward {
+			if len(reward) != 0 && reward[0] != nil {
+				rewards = append(rewards, reward[0])
+			}
+		}
+
+		if len(rewards) != 0 {
+			sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+			return new(big.Int).Set(rewards[len(rewards)/2]), nil
+		}
+
+		log.Debug("No priority fees in fee history, use the suggested gas tip cap")
+	}
+
+	gasTipCap, err := s.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		if rpc.IsMaxPriorityFeePerGasNotFoundError(err) {
+			return new(big.Int).Set(rpc.FallbackGasTipCap), nil
+		}
+		return nil, err
+	}
+
+	return gasTipCap, nil
+}
+
+// maxGasFeeCapEnabled returns whether the max gas fee cap is configured.
+func (s *FeeStrategy) maxGasFeeCapEnabled() bool {
+	return s.cfg.MaxGasFeeCap != nil && s.cfg.MaxGasFeeCap.Sign() > 0
+}
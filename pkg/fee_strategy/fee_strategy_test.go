@@ -0,0 +1,122 @@
+package fee_strategy
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// mockBackend is an in-memory Backend implementation.
+type mockBackend struct {
+	baseFee   *big.Int // nil for the chains without London
+	gasTipCap *big.Int // nil if `eth_maxPriorityFeePerGas` is not supported
+	gasPrice  *big.Int
+	rewards   []*big.Int
+}
+
+func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: b.baseFee}, nil
+}
+
+func (b *mockBackend) FeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	lastBlock *big.Int,
+	rewardPercentiles []float64,
+) (*ethereum.FeeHistory, error) {
+	history := &ethereum.FeeHistory{}
+	for _, reward := range b.rewards {
+		history.Reward = append(history.Reward, []*big.Int{reward})
+	}
+	return history, nil
+}
+
+func (b *mockBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if b.gasTipCap == nil {
+		return nil, errors.New("Method eth_maxPriorityFeePerGas not found")
+	}
+	return b.gasTipCap, nil
+}
+
+func (b *mockBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return b.gasPrice, nil
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.Nil(t, (&Config{}).Validate())
+	require.Nil(t, (&Config{BaseFeeMultiplier: 1.5, TipPercentile: 50, MaxGasFeeCap: big.NewInt(1)}).Validate())
+	require.NotNil(t, (&Config{BaseFeeMultiplier: 0.5}).Validate())
+	require.NotNil(t, (&Config{TipPercentile: 101}).Validate())
+	require.NotNil(t, (&Config{MaxGasFeeCap: big.NewInt(-1)}).Validate())
+}
+
+func TestSuggestFees(t *testing.T) {
+	backend := &mockBackend{baseFee: big.NewInt(100), gasTipCap: big.NewInt(10), gasPrice: big.NewInt(150)}
+
+	// Default multiplier.
+	fees, err := New(backend, nil).SuggestFees(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(10), fees.GasTipCap)
+	require.Equal(t, big.NewInt(210), fees.GasFeeCap)
+	require.Nil(t, fees.GasPrice)
+	require.Equal(t, big.NewInt(110), fees.EffectiveGasPrice())
+
+	// Tip from the fee history median.
+	backend.rewards = []*big.Int{big.NewInt(5), big.NewInt(30), big.NewInt(20)}
+	fees, err = New(backend, &Config{BaseFeeMultiplier: 1.5, TipPercentile: 50}).SuggestFees(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(20), fees.GasTipCap)
+	require.Equal(t, big.NewInt(170), fees.GasFeeCap)
+
+	// Limited by the max gas fee cap.
+	fees, err = New(backend, &Config{MaxGasFeeCap: big.NewInt(15)}).SuggestFees(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(10), fees.GasTipCap)
+	require.Equal(t, big.NewInt(15), fees.GasFeeCap)
+
+	fees, err = New(backend, &Config{MaxGasFeeCap: big.NewInt(5)}).SuggestFees(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(5), fees.GasTipCap)
+	require.Equal(t, big.NewInt(5), fees.GasFeeCap)
+
+	// Fallback gas tip cap.
+	backend.gasTipCap = nil
+	fees, err = New(backend, nil).SuggestFees(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, rpc.FallbackGasTipCap, fees.GasTipCap)
+
+	// Legacy gas price.
+	fees, err = New(backend, &Config{Legacy: true, MaxGasFeeCap: big.NewInt(120)}).SuggestFees(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(120), fees.GasPrice)
+	require.Nil(t, fees.GasFeeCap)
+	require.Equal(t, big.NewInt(120), fees.EffectiveGasPrice())
+
+	// Chain without London.
+	backend.baseFee = nil
+	fees, err = New(backend, nil).SuggestFees(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(150), fees.GasPrice)
+}
+
+func TestApply(t *testing.T) {
+	backend := &mockBackend{baseFee: big.NewInt(100), gasTipCap: big.NewInt(10), gasPrice: big.NewInt(150)}
+
+	opts := &bind.TransactOpts{}
+	require.Nil(t, New(backend, nil).Apply(context.Background(), opts))
+	require.Equal(t, big.NewInt(10), opts.GasTipCap)
+	require.Equal(t, big.NewInt(210), opts.GasFeeCap)
+	require.Nil(t, opts.GasPrice)
+
+	require.Nil(t, New(backend, &Config{Legacy: true}).Apply(context.Background(), opts))
+	require.Equal(t, big.NewInt(150), opts.GasPrice)
+	require.Nil(t, opts.GasTipCap)
+	require.Nil(t, opts.GasFeeCap)
+}
@@ -0,0 +1,66 @@
+package jwt
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v4"
+)
+
+// tokenExpiryTimeout is the maximum drift allowed between a token's issued-at time and now.
+const tokenExpiryTimeout = 60 * time.Second
+
+// handler is a http.Handler which authenticates the requests with JWT tokens.
+type handler struct {
+	secret []byte
+	next   http.Handler
+}
+
+// NewHandler creates a new http.Handler which only passes the requests carrying a valid
+// HS256 JWT token signed by the given secret to `next`, same as the authenticated
+// RPC endpoints of execution engines.
+func NewHandler(secret []byte, next http.Handler) http.Handler {
+	return &handler{secret: secret, next: next}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		strToken string
+		claims   gojwt.RegisteredClaims
+	)
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		strToken = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if len(strToken) == 0 {
+		http.Error(w, "missing token", http.StatusForbidden)
+		return
+	}
+
+	// Only HS256 is allowed, and the issued-at claim is checked below to allow a bit of drift.
+	token, err := gojwt.ParseWithClaims(
+		strToken,
+		&claims,
+		func(token *gojwt.Token) (interface{}, error) { return h.secret, nil },
+		gojwt.WithValidMethods([]string{"HS256"}),
+		gojwt.WithoutClaimsValidation(),
+	)
+
+	switch {
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case !token.Valid:
+		http.Error(w, "invalid token", http.StatusForbidden)
+	case !claims.VerifyExpiresAt(time.Now(), false):
+		http.Error(w, "token is expired", http.StatusForbidden)
+	case claims.IssuedAt == nil:
+		http.Error(w, "missing issued-at", http.StatusForbidden)
+	case time.Since(claims.IssuedAt.Time) > tokenExpiryTimeout:
+		http.Error(w, "stale token", http.StatusForbidden)
+	case time.Until(claims.IssuedAt.Time) > tokenExpiryTimeout:
+		http.Error(w, "future token", http.StatusForbidden)
+	default:
+		h.next.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,54 @@
+package jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	secret := []byte("TestHandler")
+
+	srv := httptest.NewServer(NewHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+	defer srv.Close()
+
+	newToken := func(secret []byte, issuedAt time.Time) string {
+		token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, gojwt.RegisteredClaims{
+			IssuedAt: gojwt.NewNumericDate(issuedAt),
+		}).SignedString(secret)
+		require.Nil(t, err)
+		return token
+	}
+
+	for _, testCase := range []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"valid", newToken(secret, time.Now()), http.StatusOK},
+		{"missing", "", http.StatusForbidden},
+		{"wrongSecret", newToken([]byte("wrong"), time.Now()), http.StatusForbidden},
+		{"stale", newToken(secret, time.Now().Add(-2*tokenExpiryTimeout)), http.StatusForbidden},
+		{"future", newToken(secret, time.Now().Add(2*tokenExpiryTimeout)), http.StatusForbidden},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, srv.URL, nil)
+			require.Nil(t, err)
+			if testCase.token != "" {
+				req.Header.Set("Authorization", "Bearer "+testCase.token)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.Nil(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, testCase.status, res.StatusCode)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
@@ -184,7 +185,19 @@ type TxLists []types.Transactions
 func (pc PoolContent) ToTxLists() TxLists {
 	txLists := make([]types.Transactions, 0)
 
-	for _, pendingTxs := range pc {
+	for _, txs := range pc.ToSenderTxLists() {
+		txLists = append(txLists, txs)
+	}
+
+	return txLists
+}
+
+// ToSenderTxLists groups all transactions in pool content by their senders,
+// each list is sorted by nonce.
+func (pc PoolContent) ToSenderTxLists() map[common.Address]types.Transactions {
+	txLists := make(map[common.Address]types.Transactions, len(pc))
+
+	for sender, pendingTxs := range pc {
 		var txsByNonce types.TxByNonce
 
 		for _, pendingTx := range pendingTxs {
@@ -193,7 +206,7 @@ func (pc PoolContent) ToTxLists() TxLists {
 
 		sort.Sort(txsByNonce)
 
-		txLists = append(txLists, types.Transactions(txsByNonce))
+		txLists[sender] = types.Transactions(txsByNonce)
 	}
 
 	return txLists
@@ -218,6 +231,16 @@ func (c *Client) L2PoolContent(ctx context.Context) (pending PoolContent, queued
 	return res["pending"], res["queued"], nil
 }
 
+// L2PoolStatus fetches the numbers of the pending and queued transactions in L2 node's transaction pool.
+func (c *Client) L2PoolStatus(ctx context.Context) (pending uint64, queued uint64, err error) {
+	var res map[string]hexutil.Uint64
+	if err := c.L2RawRPC.CallContext(ctx, &res, "txpool_status"); err != nil {
+		return 0, 0, err
+	}
+
+	return uint64(res["pending"]), uint64(res["queued"]), nil
+}
+
 // L2AccountNonce fetches the nonce of the given L2 account at a specified height.
 func (c *Client) L2AccountNonce(
 	ctx context.Context,
@@ -228,3 +251,59 @@ func (c *Client) L2AccountNonce(
 	err := c.L2RawRPC.CallContext(ctx, &result, "eth_getTransactionCount", account, hexutil.EncodeBig(height))
 	return uint64(result), err
 }
+
+// AccountState represents the nonce and balance of an account.
+type AccountState struct {
+	Nonce   uint64
+	Balance *big.Int
+}
+
+// L2AccountsState fetches the states of the given L2 accounts at a specified height,
+// in batched RPC calls.
+func (c *Client) L2AccountsState(
+	ctx context.Context,
+	accounts []common.Address,
+	height *big.Int,
+) (map[common.Address]*AccountState, error) {
+	const maxAccountsPerBatch = 100
+
+	var (
+		states = make(map[common.Address]*AccountState, len(accounts))
+		block  = hexutil.EncodeBig(height)
+	)
+	for start := 0; start < len(accounts); start += maxAccountsPerBatch {
+		end := start + maxAccountsPerBatch
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+
+		var (
+			nonces   = make([]hexutil.Uint64, end-start)
+			balances = make([]hexutil.Big, end-start)
+			batch    = make([]rpc.BatchElem, 0, 2*(end-start))
+		)
+		for i, account := range accounts[start:end] {
+			batch = append(
+				batch,
+				rpc.BatchElem{Method: "eth_getTransactionCount", Args: []interface{}{account, block}, Result: &nonces[i]},
+				rpc.BatchElem{Method: "eth_getBalance", Args: []interface{}{account, block}, Result: &balances[i]},
+			)
+		}
+
+		if err := c.L2RawRPC.BatchCallContext(ctx, batch); err != nil {
+			return nil, err
+		}
+
+		for _, elem := range batch {
+			if elem.Error != nil {
+				return nil, fmt.Errorf("failed to fetch account state: %w", elem.Error)
+			}
+		}
+
+		for i, account := range accounts[start:end] {
+			states[account] = &AccountState{Nonce: uint64(nonces[i]), Balance: balances[i].ToInt()}
+		}
+	}
+
+	return states, nil
+}
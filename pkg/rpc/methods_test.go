@@ -24,6 +24,13 @@ func TestL2PoolContent(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestL2PoolStatus(t *testing.T) {
+	client := newTestClient(t)
+
+	_, _, err := client.L2PoolStatus(context.Background())
+	require.Nil(t, err)
+}
+
 func TestL2AccountNonce(t *testing.T) {
 	client := newTestClient(t)
 
@@ -33,6 +40,17 @@ func TestL2AccountNonce(t *testing.T) {
 	require.Zero(t, nonce)
 }
 
+func TestL2AccountsState(t *testing.T) {
+	client := newTestClient(t)
+
+	states, err := client.L2AccountsState(context.Background(), []common.Address{testAddress1, testAddress2}, common.Big0)
+
+	require.Nil(t, err)
+	require.Len(t, states, 2)
+	require.Zero(t, states[testAddress1].Nonce)
+	require.NotNil(t, states[testAddress2].Balance)
+}
+
 func TestPoolContentToTxLists(t *testing.T) {
 	poolContent := &PoolContent{
 		testAddress1: map[string]*types.Transaction{
@@ -67,6 +85,25 @@ func TestPoolContentToTxLists(t *testing.T) {
 	require.Equal(t, 5, txLists.Len())
 }
 
+func TestPoolContentToSenderTxLists(t *testing.T) {
+	poolContent := PoolContent{
+		testAddress1: map[string]*types.Transaction{
+			"1": types.NewTransaction(1, common.Address{}, common.Big0, 0, common.Big0, []byte{}),
+			"0": types.NewTransaction(0, common.Address{}, common.Big0, 0, common.Big0, []byte{}),
+		},
+		testAddress2: map[string]*types.Transaction{
+			"3": types.NewTransaction(3, common.Address{}, common.Big0, 0, common.Big0, []byte{}),
+		},
+	}
+
+	txLists := poolContent.ToSenderTxLists()
+
+	require.Equal(t, 2, len(txLists))
+	require.Equal(t, uint64(0), txLists[testAddress1][0].Nonce())
+	require.Equal(t, uint64(1), txLists[testAddress1][1].Nonce())
+	require.Equal(t, uint64(3), txLists[testAddress2][0].Nonce())
+}
+
 func TestGetGenesisL1Header(t *testing.T) {
 	client := newTestClient(t)
 
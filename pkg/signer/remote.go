@@ -0,0 +1,114 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// remoteSigner signs the transactions through the `eth_signTransaction` JSON-RPC method of
+// a remote signer service, such as Clef or web3signer.
+type remoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewRemoteSigner creates a new Signer instance using the account held by the given remote signer.
+func NewRemoteSigner(ctx context.Context, endpoint string, address common.Address) (Signer, error) {
+	if address == (common.Address{}) {
+		return nil, fmt.Errorf("remote signer account address is required")
+	}
+
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer: %w", err)
+	}
+
+	return &remoteSigner{client: client, address: address}, nil
+}
+
+// Address implements the Signer interface.
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx implements the Signer interface.
+func (s *remoteSigner) SignTx(
+	ctx context.Context,
+	tx *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := &apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		accessList := tx.AccessList()
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = &accessList
+	default:
+		return nil, fmt.Errorf("unsupported transaction type: %d", tx.Type())
+	}
+
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction through remote signer: %w", err)
+	}
+
+	// web3signer returns the raw signed transaction, while Clef returns an object
+	// containing both the raw and the decoded signed transaction.
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err != nil {
+		var res struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := json.Unmarshal(result, &res); err != nil {
+			return nil, fmt.Errorf("invalid eth_signTransaction result: %w", err)
+		}
+		raw = res.Raw
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+
+	// Make sure the remote signer signed exactly the given transaction with the expected account.
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signed) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer signed a different transaction: %s", signed.Hash())
+	}
+
+	sender, err := types.Sender(txSigner, signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signer signature: %w", err)
+	}
+
+	if sender != s.address {
+		return nil, fmt.Errorf("transaction signed by unexpected account: %s", sender)
+	}
+
+	return signed, nil
+}
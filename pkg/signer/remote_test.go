@@ -0,0 +1,110 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSignerAPI is a mock remote signer serving the `eth_signTransaction` JSON-RPC method.
+type mockSignerAPI struct {
+	privKey *ecdsa.PrivateKey
+	clef    bool // Return the result in Clef's format
+	tamper  bool // Sign a different transaction
+}
+
+// SignTransaction signs the given transaction with the mock signer's private key.
+func (api *mockSignerAPI) SignTransaction(args apitypes.SendTxArgs) (interface{}, error) {
+	if api.tamper {
+		args.Nonce++
+	}
+
+	signed, err := types.SignTx(
+		args.ToTransaction(),
+		types.LatestSignerForChainID((*hexutil.Big)(args.ChainID).ToInt()),
+		api.privKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	if api.clef {
+		return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+	}
+
+	return hexutil.Bytes(raw), nil
+}
+
+// newMockRemoteSigner starts a mock remote signer server, and returns its endpoint.
+func newMockRemoteSigner(t *testing.T, api *mockSignerAPI) string {
+	srv := rpc.NewServer()
+	require.Nil(t, srv.RegisterName("eth", api))
+
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+
+	return httpSrv.URL
+}
+
+func TestRemoteSigner(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	for _, clef := range []bool{false, true} {
+		endpoint := newMockRemoteSigner(t, &mockSignerAPI{privKey: privKey, clef: clef})
+
+		s, err := New(context.Background(), &Config{RemoteSignerEndpoint: endpoint, RemoteSignerAddress: address.Hex()})
+		require.Nil(t, err)
+		require.Equal(t, address, s.Address())
+
+		testSignTx(t, s)
+	}
+}
+
+func TestRemoteSignerUnexpectedSignature(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: testChainID, Gas: 21000, GasFeeCap: common.Big1})
+
+	// Signed by another account.
+	s, err := NewRemoteSigner(
+		context.Background(),
+		newMockRemoteSigner(t, &mockSignerAPI{privKey: privKey}),
+		common.BytesToAddress([]byte{0x01}),
+	)
+	require.Nil(t, err)
+
+	_, err = s.SignTx(context.Background(), tx, testChainID)
+	require.ErrorContains(t, err, "unexpected account")
+
+	// Signed a different transaction.
+	s, err = NewRemoteSigner(
+		context.Background(),
+		newMockRemoteSigner(t, &mockSignerAPI{privKey: privKey, tamper: true}),
+		crypto.PubkeyToAddress(privKey.PublicKey),
+	)
+	require.Nil(t, err)
+
+	_, err = s.SignTx(context.Background(), tx, testChainID)
+	require.ErrorContains(t, err, "different transaction")
+}
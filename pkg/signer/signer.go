@@ -0,0 +1,131 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs the transactions sent from an account, the account's private key may
+// be held locally or by a remote signer service.
+type Signer interface {
+	// Address returns the address of the account.
+	Address() common.Address
+	// SignTx signs the given transaction with the given chain ID.
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Config contains the configurations to create a Signer, exactly one of the private key,
+// the keystore file or the remote signer should be set.
+type Config struct {
+	PrivKey              string // Hex encoded private key
+	KeystorePath         string // Path of an encrypted keystore file
+	KeystorePasswordFile string // Path of a file containing the keystore password
+	RemoteSignerEndpoint string // RPC endpoint of a Clef / web3signer remote signer
+	RemoteSignerAddress  string // Address of the account held by the remote signer
+}
+
+// New creates a new Signer instance based on the given configurations.
+func New(ctx context.Context, cfg *Config) (Signer, error) {
+	var sources int
+	for _, source := range []string{cfg.PrivKey, cfg.KeystorePath, cfg.RemoteSignerEndpoint} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return nil, errors.New("exactly one of private key, keystore file and remote signer should be set")
+	}
+
+	switch {
+	case cfg.PrivKey != "":
+		privKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+		return NewPrivateKeySigner(privKey), nil
+	case cfg.KeystorePath != "":
+		return NewKeystoreSigner(cfg.KeystorePath, cfg.KeystorePasswordFile)
+	default:
+		if !common.IsHexAddress(cfg.RemoteSignerAddress) {
+			return nil, fmt.Errorf("invalid remote signer account address: %s", cfg.RemoteSignerAddress)
+		}
+		return NewRemoteSigner(ctx, cfg.RemoteSignerEndpoint, common.HexToAddress(cfg.RemoteSignerAddress))
+	}
+}
+
+// privateKeySigner signs the transactions with a local private key.
+type privateKeySigner struct {
+	privKey *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewPrivateKeySigner creates a new Signer instance using the given private key.
+func NewPrivateKeySigner(privKey *ecdsa.PrivateKey) Signer {
+	return &privateKeySigner{privKey: privKey, address: crypto.PubkeyToAddress(privKey.PublicKey)}
+}
+
+// NewKeystoreSigner creates a new Signer instance using the private key decrypted from
+// the given keystore file.
+func NewKeystoreSigner(path string, passwordFile string) (Signer, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	var password string
+	if passwordFile != "" {
+		b, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore password file: %w", err)
+		}
+		password = strings.TrimRight(string(b), "\r\n")
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore file: %w", err)
+	}
+
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
+
+// Address implements the Signer interface.
+func (s *privateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx implements the Signer interface.
+func (s *privateKeySigner) SignTx(
+	ctx context.Context,
+	tx *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privKey)
+}
+
+// NewTransactOpts creates a new bind.TransactOpts instance which signs the transactions
+// with the given Signer.
+func NewTransactOpts(ctx context.Context, s Signer, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: s.Address(),
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(ctx, tx, chainID)
+		},
+		Context: ctx,
+	}
+}
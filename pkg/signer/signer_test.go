@@ -0,0 +1,122 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainID = big.NewInt(167)
+
+func TestPrivateKeySigner(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	s := NewPrivateKeySigner(privKey)
+	require.Equal(t, crypto.PubkeyToAddress(privKey.PublicKey), s.Address())
+
+	testSignTx(t, s)
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	dir := t.TempDir()
+
+	account, err := keystore.StoreKey(dir, "password", keystore.LightScryptN, keystore.LightScryptP)
+	require.Nil(t, err)
+
+	keystorePath := account.URL.Path
+	passwordFile := filepath.Join(dir, "password")
+	require.Nil(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+
+	s, err := New(context.Background(), &Config{KeystorePath: keystorePath, KeystorePasswordFile: passwordFile})
+	require.Nil(t, err)
+	require.Equal(t, account.Address, s.Address())
+
+	testSignTx(t, s)
+
+	// Wrong password
+	_, err = NewKeystoreSigner(keystorePath, "")
+	require.ErrorContains(t, err, "failed to decrypt keystore file")
+}
+
+func TestNew(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	s, err := New(context.Background(), &Config{PrivKey: common.Bytes2Hex(crypto.FromECDSA(privKey))})
+	require.Nil(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(privKey.PublicKey), s.Address())
+
+	_, err = New(context.Background(), &Config{})
+	require.NotNil(t, err)
+
+	_, err = New(context.Background(), &Config{PrivKey: "invalid"})
+	require.ErrorContains(t, err, "invalid private key")
+
+	_, err = New(context.Background(), &Config{
+		PrivKey:              common.Bytes2Hex(crypto.FromECDSA(privKey)),
+		RemoteSignerEndpoint: "http://localhost:8550",
+	})
+	require.NotNil(t, err)
+
+	_, err = New(context.Background(), &Config{RemoteSignerEndpoint: "http://localhost:8550"})
+	require.ErrorContains(t, err, "invalid remote signer account address")
+}
+
+func TestNewTransactOpts(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	s := NewPrivateKeySigner(privKey)
+	opts := NewTransactOpts(context.Background(), s, testChainID)
+	require.Equal(t, s.Address(), opts.From)
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: testChainID, Gas: 21000, GasFeeCap: common.Big1})
+
+	signed, err := opts.Signer(s.Address(), tx)
+	require.Nil(t, err)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	require.Nil(t, err)
+	require.Equal(t, s.Address(), sender)
+
+	_, err = opts.Signer(common.Address{}, tx)
+	require.ErrorIs(t, err, bind.ErrNotAuthorized)
+}
+
+// testSignTx checks whether the given Signer signs both legacy and dynamic fee transactions
+// with its account.
+func testSignTx(t *testing.T, s Signer) {
+	to := common.BytesToAddress([]byte{0x01})
+
+	for _, tx := range []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: common.Big1, Gas: 21000, To: &to, Value: common.Big1}),
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID:   testChainID,
+			Nonce:     2,
+			GasTipCap: common.Big1,
+			GasFeeCap: common.Big2,
+			Gas:       50000,
+			Data:      []byte{0x01, 0x02},
+		}),
+	} {
+		signed, err := s.SignTx(context.Background(), tx, testChainID)
+		require.Nil(t, err)
+
+		txSigner := types.LatestSignerForChainID(testChainID)
+		require.Equal(t, txSigner.Hash(tx), txSigner.Hash(signed))
+
+		sender, err := types.Sender(txSigner, signed)
+		require.Nil(t, err)
+		require.Equal(t, s.Address(), sender)
+	}
+}
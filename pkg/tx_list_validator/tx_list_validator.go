@@ -36,12 +36,47 @@ const (
 	HintTxGasLimitTooSmall
 )
 
+// String implements the fmt.Stringer interface.
+func (r InvalidTxListReason) String() string {
+	switch r {
+	case HintOK:
+		return "OK"
+	case HintBinaryTooLarge:
+		return "BINARY_TOO_LARGE"
+	case HintBinaryNotDecodable:
+		return "BINARY_NOT_DECODABLE"
+	case HintBlockTooManyTxs:
+		return "BLOCK_TOO_MANY_TXS"
+	case HintBlockGasLimitTooLarge:
+		return "BLOCK_GAS_LIMIT_TOO_LARGE"
+	case HintTxInvalidSig:
+		return "TX_INVALID_SIG"
+	case HintTxGasLimitTooSmall:
+		return "TX_GAS_LIMIT_TOO_SMALL"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", uint8(r))
+	}
+}
+
+// ParseInvalidTxListReason parses the given reason name, which is the same as
+// the name returned by InvalidTxListReason.String.
+func ParseInvalidTxListReason(name string) (InvalidTxListReason, error) {
+	for r := HintOK; r <= HintTxGasLimitTooSmall; r++ {
+		if r.String() == name {
+			return r, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown invalid transactions list reason: %s", name)
+}
+
 type TxListValidator struct {
 	maxBlocksGasLimit uint64
 	maxBlockNumTxs    uint64
 	maxTxlistBytes    uint64
 	minTxGasLimit     uint64
 	chainID           *big.Int
+	compressed        bool // Whether the compressed transactions lists are accepted
 }
 
 // NewTxListValidator creates a new TxListValidator instance based on giving configurations.
@@ -51,6 +86,7 @@ func NewTxListValidator(
 	maxTxlistBytes uint64,
 	minTxGasLimit uint64,
 	chainID *big.Int,
+	compressed bool,
 ) *TxListValidator {
 	return &TxListValidator{
 		maxBlocksGasLimit: maxBlocksGasLimit,
@@ -58,6 +94,7 @@ func NewTxListValidator(
 		maxTxlistBytes:    maxTxlistBytes,
 		minTxGasLimit:     minTxGasLimit,
 		chainID:           chainID,
+		compressed:        compressed,
 	}
 }
 
@@ -67,7 +104,7 @@ func (v *TxListValidator) ValidateTxList(
 	blockID *big.Int,
 	proposeBlockTxInput []byte,
 ) (hint InvalidTxListReason, txIdx int, err error) {
-	txListBytes, err := encoding.UnpackTxListBytes(proposeBlockTxInput)
+	txListBytes, err := encoding.UnpackRawTxListBytes(proposeBlockTxInput)
 	if err != nil {
 		return HintBinaryNotDecodable, 0, fmt.Errorf("failed to unpack raw transactions list bytes: %w", err)
 	}
@@ -78,7 +115,10 @@ func (v *TxListValidator) ValidateTxList(
 }
 
 // IsTxListValid checks whether the transaction list is valid, must match
-// the validation rule defined in LibInvalidTxList.sol.
+// the validation rule defined in LibInvalidTxList.sol. The given transactions list
+// is the payload in TaikoL1.proposeBlock transaction's calldata, which is only
+// decompressed if the compressed transactions lists are accepted, since LibInvalidTxList.sol
+// doesn't support the compression yet.
 // ref: https://github.com/taikoxyz/taiko-mono/blob/main/packages/bindings/contracts/libs/LibInvalidTxList.sol
 func (v *TxListValidator) IsTxListValid(blockID *big.Int, txListBytes []byte) (hint InvalidTxListReason, txIdx int) {
 	if len(txListBytes) > int(v.maxTxlistBytes) {
@@ -86,6 +126,15 @@ func (v *TxListValidator) IsTxListValid(blockID *big.Int, txListBytes []byte) (h
 		return HintBinaryTooLarge, 0
 	}
 
+	if v.compressed {
+		decompressed, err := encoding.DecompressTxListBytes(txListBytes)
+		if err != nil {
+			log.Info("Failed to decompress transactions list bytes", "blockID", blockID, "error", err)
+			return HintBinaryNotDecodable, 0
+		}
+		txListBytes = decompressed
+	}
+
 	var txs types.Transactions
 	if err := rlp.DecodeBytes(txListBytes, &txs); err != nil {
 		log.Info("Failed to decode transactions list bytes", "blockID", blockID, "error", err)
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 )
 
 var (
@@ -37,6 +38,7 @@ func TestValidateTxList(t *testing.T) {
 		maxTxlistBytes,
 		minTxGasLimit,
 		chainID,
+		true,
 	)
 	tests := []struct {
 		name                string
@@ -73,6 +75,7 @@ func TestIsTxListValid(t *testing.T) {
 		maxTxlistBytes,
 		minTxGasLimit,
 		chainID,
+		true,
 	)
 	tests := []struct {
 		name        string
@@ -130,6 +133,34 @@ func TestIsTxListValid(t *testing.T) {
 			HintOK,
 			0,
 		},
+		{
+			"compressed txListBytes not decodable",
+			chainID,
+			[]byte{encoding.TxListCodecZlib, 0x01, 0x02, 0x03},
+			HintBinaryNotDecodable,
+			0,
+		},
+		{
+			"txListBytes unknown version tag",
+			chainID,
+			append([]byte{0x7f}, rlpEncodedTransactionBytes(1, true)...),
+			HintBinaryNotDecodable,
+			0,
+		},
+		{
+			"compressed txListBytes too many transactions",
+			chainID,
+			compressedTransactionBytes(int(maxBlockNumTxs)+1, true),
+			HintBlockTooManyTxs,
+			0,
+		},
+		{
+			"success compressed tx list",
+			chainID,
+			compressedTransactionBytes(1, true),
+			HintOK,
+			0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -141,6 +172,24 @@ func TestIsTxListValid(t *testing.T) {
 	}
 }
 
+func TestIsTxListValidCompressionDisabled(t *testing.T) {
+	v := NewTxListValidator(
+		maxBlocksGasLimit,
+		maxBlockNumTxs,
+		maxTxlistBytes,
+		minTxGasLimit,
+		chainID,
+		false,
+	)
+
+	reason, _ := v.IsTxListValid(chainID, rlpEncodedTransactionBytes(1, true))
+	require.Equal(t, HintOK, reason)
+
+	// The compressed transactions lists are not decodable, as in LibInvalidTxList.sol.
+	reason, _ = v.IsTxListValid(chainID, compressedTransactionBytes(1, true))
+	require.Equal(t, HintBinaryNotDecodable, reason)
+}
+
 func rlpEncodedTransactionBytes(l int, signed bool) []byte {
 	txs := make(types.Transactions, 0)
 	for i := 0; i < l; i++ {
@@ -167,8 +216,29 @@ func rlpEncodedTransactionBytes(l int, signed bool) []byte {
 	return b
 }
 
+func compressedTransactionBytes(l int, signed bool) []byte {
+	b, _ := encoding.CompressTxListBytes(rlpEncodedTransactionBytes(l, signed), encoding.TxListCodecZlib)
+	return b
+}
+
 func randBytes(l uint64) []byte {
 	b := make([]byte, l)
 	rand.Read(b)
 	return b
 }
+
+func TestInvalidTxListReasonString(t *testing.T) {
+	require.Equal(t, "OK", HintOK.String())
+	require.Equal(t, "TX_INVALID_SIG", HintTxInvalidSig.String())
+	require.Equal(t, "TX_GAS_LIMIT_TOO_SMALL", HintTxGasLimitTooSmall.String())
+	require.Equal(t, "UNKNOWN(255)", InvalidTxListReason(255).String())
+
+	for r := HintOK; r <= HintTxGasLimitTooSmall; r++ {
+		parsed, err := ParseInvalidTxListReason(r.String())
+		require.Nil(t, err)
+		require.Equal(t, r, parsed)
+	}
+
+	_, err := ParseInvalidTxListReason("UNKNOWN(255)")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,528 @@
+package tx_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// ErrNonceUsed is returned when the nonce of a transaction has been used by another transaction.
+	ErrNonceUsed = errors.New("transaction nonce has been used by another transaction")
+
+	errMaxGasFeeCapReached = errors.New("transaction still not mined after reaching the max gas fee cap")
+)
+
+// Backend contains all L1 node RPC methods used by TxManager, *ethclient.Client implements
+// this interface.
+type Backend interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Config contains all configurations of a TxManager.
+type Config struct {
+	ResubmissionTimeout  time.Duration // Re-broadcast the transaction with bumped fees if not mined in time, 0 to disable
+	FeeBumpPercentage    uint64        // Percentage of the fees bump when re-broadcasting
+	MaxGasFeeCap         *big.Int      // Give up re-broadcasting once the gas fee cap reaches this limit
+	ReceiptQueryInterval time.Duration // Interval between the transaction receipt queries
+}
+
+// TxManager manages the nonces of an L1 account locally, and keeps re-broadcasting
+// the sent transactions with bumped fees if they get stuck. The local nonce is synced
+// from the L1 node's pending nonce on the first send, and again after any send error.
+type TxManager struct {
+	backend   Backend
+	getTxOpts func(ctx context.Context) (*bind.TransactOpts, error)
+	cfg       *Config
+
+	sendMu sync.Mutex // Serializes the sends, so that the nonces are assigned and broadcasted in order
+
+	mu        sync.Mutex                 // Protects the fields below, never held during RPC calls
+	nextNonce *uint64                    // Next nonce to use, nil if need to be synced from the L1 node
+	pending   map[common.Hash]*pendingTx // All versions of all pending transactions, keyed by hash
+}
+
+// pendingTx represents a sent transaction which has not been mined yet, including
+// all its re-broadcasted versions.
+type pendingTx struct {
+	from     common.Address
+	signer   bind.SignerFn
+	versions []*types.Transaction // All broadcasted versions, the latest one is the last
+	sentAt   time.Time            // Time of the latest broadcast
+}
+
+// New creates a new TxManager instance, `getTxOpts` creates a new bind.TransactOpts instance
+// for signing the transactions.
+func New(
+	backend Backend,
+	getTxOpts func(ctx context.Context) (*bind.TransactOpts, error),
+	cfg *Config,
+) *TxManager {
+	return &TxManager{
+		backend:   backend,
+		getTxOpts: getTxOpts,
+		cfg:       cfg,
+		pending:   make(map[common.Hash]*pendingTx),
+	}
+}
+
+// Send assigns the next local nonce to the transaction created by `build`, and then
+// broadcasts it. `build` *MUST* create the transaction with the given bind.TransactOpts.
+func (m *TxManager) Send(
+	ctx context.Context,
+	build func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*types.Transaction, error) {
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+
+	opts, err := m.getTxOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tx        *types.Transaction
+		usedNonce *uint64 // Nonce found used by another transaction in the last attempt
+	)
+	for {
+		nonce, err := m.getNonce(ctx, opts.From)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Context = ctx
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+		opts.NoSend = true
+
+		if tx, err = build(opts); err != nil {
+			return nil, err
+		}
+
+		if m.cfg.MaxGasFeeCap != nil && tx.GasFeeCapIntCmp(m.cfg.MaxGasFeeCap) > 0 {
+			gasTipCap := tx.GasTipCap()
+			if gasTipCap.Cmp(m.cfg.MaxGasFeeCap) > 0 {
+				gasTipCap = m.cfg.MaxGasFeeCap
+			}
+			if tx, err = m.resign(opts.From, opts.Signer, tx, gasTipCap, m.cfg.MaxGasFeeCap); err != nil {
+				return nil, err
+			}
+		}
+
+		err = m.backend.SendTransaction(ctx, tx)
+		if err == nil || isErr(err, core.ErrAlreadyKnown) {
+			break
+		}
+
+		// The local nonce has been used by another transaction, which is either mined or still in
+		// the L1 node's pool, resync it from the L1 node and try again.
+		if (isErr(err, core.ErrNonceTooLow) || isErr(err, core.ErrReplaceUnderpriced)) &&
+			(usedNonce == nil || *usedNonce != nonce) {
+			log.Warn("Local nonce has been used, resync it from L1 node", "nonce", nonce, "error", err)
+			usedNonce = &nonce
+			m.resetNonce()
+			continue
+		}
+
+		// The transaction might have been broadcasted anyway, resync the nonce next time.
+		m.resetNonce()
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nextNonce := tx.Nonce() + 1
+	m.nextNonce = &nextNonce
+	m.pending[tx.Hash()] = &pendingTx{
+		from:     opts.From,
+		signer:   opts.Signer,
+		versions: []*types.Transaction{tx},
+		sentAt:   time.Now(),
+	}
+
+	log.Debug("Transaction sent", "hash", tx.Hash(), "nonce", tx.Nonce())
+
+	return tx, nil
+}
+
+// Replace sends the transaction created by `build` with the nonce of the given transaction, which
+// has been mined but then reorged out, so that at most one of them can be mined again. The fees are
+// bumped from the given transaction's ones, to replace it in the L1 node's pool. Returns an error
+// wrapping ErrNonceUsed if the nonce has been used again. `build` *MUST* create the transaction
+// with the given bind.TransactOpts.
+func (m *TxManager) Replace(
+	ctx context.Context,
+	replaced *types.Transaction,
+	build func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*types.Transaction, error) {
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+
+	opts, err := m.getTxOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Context = ctx
+	opts.Nonce = new(big.Int).SetUint64(replaced.Nonce())
+	opts.NoSend = true
+
+	tx, err := build(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pay at least the bumped fees of the replaced transaction, which never exceed the max gas fee cap.
+	gasTipCap, gasFeeCap := m.bumpFees(replaced)
+	if tx.GasFeeCapIntCmp(gasFeeCap) > 0 && (m.cfg.MaxGasFeeCap == nil || tx.GasFeeCapIntCmp(m.cfg.MaxGasFeeCap) <= 0) {
+		gasFeeCap = tx.GasFeeCap()
+	}
+	if tx.GasTipCapIntCmp(gasTipCap) > 0 {
+		gasTipCap = tx.GasTipCap()
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+	if tx, err = m.resign(opts.From, opts.Signer, tx, gasTipCap, gasFeeCap); err != nil {
+		return nil, err
+	}
+
+	if err := m.backend.SendTransaction(ctx, tx); err != nil && !isErr(err, core.ErrAlreadyKnown) {
+		if isErr(err, core.ErrNonceTooLow) {
+			return nil, fmt.Errorf("%w, nonce: %d", ErrNonceUsed, tx.Nonce())
+		}
+
+		// A version of the replaced transaction with higher fees might be still in the L1 node's
+		// pool, keep waiting for the replacement anyway, so the fees are bumped further later.
+		if !isErr(err, core.ErrReplaceUnderpriced) {
+			return nil, err
+		}
+		log.Warn("Replacement transaction underpriced, bump the fees further later", "hash", tx.Hash())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pending[tx.Hash()] = &pendingTx{
+		from:     opts.From,
+		signer:   opts.Signer,
+		versions: []*types.Transaction{tx},
+		sentAt:   time.Now(),
+	}
+
+	log.Debug("Replacement transaction sent", "hash", tx.Hash(), "replaced", replaced.Hash(), "nonce", tx.Nonce())
+
+	return tx, nil
+}
+
+// getNonce returns the next local nonce, syncs it from the L1 node first if needed.
+func (m *TxManager) getNonce(ctx context.Context, account common.Address) (uint64, error) {
+	m.mu.Lock()
+	nextNonce := m.nextNonce
+	m.mu.Unlock()
+
+	if nextNonce != nil {
+		return *nextNonce, nil
+	}
+
+	nonce, err := m.backend.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch pending nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
+// resetNonce makes the local nonce synced from the L1 node on the next send.
+func (m *TxManager) resetNonce() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextNonce = nil
+}
+
+// WaitReceipt keeps waiting until one version of the given transaction has an execution
+// receipt, and re-broadcasts the transaction with bumped fees after every resubmission timeout.
+func (m *TxManager) WaitReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	ticker := time.NewTicker(m.cfg.ReceiptQueryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			receipt, err := m.checkPending(ctx, tx)
+			if err != nil {
+				return nil, err
+			}
+
+			if receipt == nil {
+				continue
+			}
+
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return nil, fmt.Errorf("transaction reverted, hash: %s", receipt.TxHash)
+			}
+
+			return receipt, nil
+		}
+	}
+}
+
+// checkPending checks whether one version of the given transaction has been mined, if
+// not, re-broadcasts it with bumped fees when the resubmission timeout is reached.
+func (m *TxManager) checkPending(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	m.mu.Lock()
+	pending, ok := m.pending[tx.Hash()]
+	var (
+		versions []*types.Transaction
+		sentAt   time.Time
+	)
+	if ok {
+		versions, sentAt = append([]*types.Transaction{}, pending.versions...), pending.sentAt
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		// Not sent by this manager, only query its receipt.
+		receipt, err := m.backend.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, nil
+		}
+		return receipt, nil
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		receipt, err := m.backend.TransactionReceipt(ctx, versions[i].Hash())
+		if err != nil || receipt == nil {
+			continue
+		}
+
+		m.removePending(pending)
+		return receipt, nil
+	}
+
+	if m.cfg.ResubmissionTimeout == 0 || time.Since(sentAt) < m.cfg.ResubmissionTimeout {
+		return nil, nil
+	}
+
+	latest := versions[len(versions)-1]
+
+	// Check whether the nonce has been used by a transaction not sent by this manager.
+	confirmedNonce, err := m.backend.NonceAt(ctx, pending.from, nil)
+	if err != nil {
+		log.Warn("Failed to fetch account nonce", "account", pending.from, "error", err)
+		return nil, nil
+	}
+
+	if confirmedNonce > latest.Nonce() {
+		// Maybe one version has just been mined, check the receipts again next time.
+		if time.Since(sentAt) < 2*m.cfg.ResubmissionTimeout {
+			return nil, nil
+		}
+
+		m.removePending(pending)
+		m.resetNonce()
+		return nil, fmt.Errorf("%w, nonce: %d", ErrNonceUsed, latest.Nonce())
+	}
+
+	// A transaction with a lower nonce might have been dropped by the L1 node, in which case this
+	// one can never be mined until the nonce gap is filled.
+	if err := m.fillNonceGap(ctx, pending.from, pending.signer, latest); err != nil {
+		log.Warn("Failed to fill nonce gap", "account", pending.from, "error", err)
+	}
+
+	if m.cfg.MaxGasFeeCap != nil && latest.GasFeeCapIntCmp(m.cfg.MaxGasFeeCap) >= 0 {
+		m.removePending(pending)
+		m.resetNonce()
+		return nil, fmt.Errorf("%w, hash: %s, nonce: %d", errMaxGasFeeCapReached, latest.Hash(), latest.Nonce())
+	}
+
+	gasTipCap, gasFeeCap := m.bumpFees(latest)
+
+	replacement, err := m.resign(pending.from, pending.signer, latest, gasTipCap, gasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(
+		"Transaction not mined in time, re-broadcast it with bumped fees",
+		"hash", latest.Hash(),
+		"newHash", replacement.Hash(),
+		"nonce", latest.Nonce(),
+		"gasTipCap", gasTipCap,
+		"gasFeeCap", gasFeeCap,
+	)
+
+	err = m.backend.SendTransaction(ctx, replacement)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending.sentAt = time.Now()
+	if err != nil && !isErr(err, core.ErrAlreadyKnown) {
+		// The L1 node might require a larger fee bump, keep the replacement as the latest
+		// version anyway, so the fees are bumped further next time.
+		if !isErr(err, core.ErrReplaceUnderpriced) {
+			log.Warn("Failed to re-broadcast transaction", "hash", replacement.Hash(), "error", err)
+			return nil, nil
+		}
+		log.Warn("Re-broadcasted transaction underpriced, bump the fees further later", "hash", replacement.Hash())
+	}
+
+	pending.versions = append(pending.versions, replacement)
+	m.pending[replacement.Hash()] = pending
+
+	return nil, nil
+}
+
+// fillNonceGap checks whether there is a nonce gap before the given transaction in the L1 node's pool,
+// if the missing nonce is not used by any transaction of this manager, fills it with an empty transfer
+// to the account itself, using the given transaction's fees.
+func (m *TxManager) fillNonceGap(
+	ctx context.Context,
+	from common.Address,
+	signer bind.SignerFn,
+	tx *types.Transaction,
+) error {
+	pendingNonce, err := m.backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	if pendingNonce >= tx.Nonce() {
+		return nil
+	}
+
+	m.mu.Lock()
+	for _, pending := range m.pending {
+		if pending.from == from && pending.versions[0].Nonce() == pendingNonce {
+			// Will be re-broadcasted by its own waiter.
+			m.mu.Unlock()
+			return nil
+		}
+	}
+	m.mu.Unlock()
+
+	filler, err := m.resign(from, signer, types.NewTx(&types.DynamicFeeTx{
+		ChainID: tx.ChainId(),
+		Nonce:   pendingNonce,
+		Gas:     params.TxGas,
+		To:      &from,
+		Value:   common.Big0,
+	}), tx.GasTipCap(), tx.GasFeeCap())
+	if err != nil {
+		return err
+	}
+
+	log.Warn("Nonce gap found, fill it with an empty transaction", "nonce", pendingNonce, "hash", filler.Hash())
+
+	return m.backend.SendTransaction(ctx, filler)
+}
+
+// bumpFees calculates the bumped fees of the given transaction, the bumped gas fee cap won't exceed
+// the configured max gas fee cap.
+func (m *TxManager) bumpFees(tx *types.Transaction) (gasTipCap *big.Int, gasFeeCap *big.Int) {
+	bump := func(n *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(n, new(big.Int).SetUint64(100+m.cfg.FeeBumpPercentage))
+		bumped.Div(bumped, big.NewInt(100))
+		// Make sure the fees are always increased, even if they are very small.
+		if bumped.Cmp(n) <= 0 {
+			bumped.Add(n, common.Big1)
+		}
+		return bumped
+	}
+
+	gasTipCap, gasFeeCap = bump(tx.GasTipCap()), bump(tx.GasFeeCap())
+
+	if m.cfg.MaxGasFeeCap != nil && gasFeeCap.Cmp(m.cfg.MaxGasFeeCap) > 0 {
+		gasFeeCap = new(big.Int).Set(m.cfg.MaxGasFeeCap)
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+
+	return gasTipCap, gasFeeCap
+}
+
+// resign creates a copy of the given transaction with the new fees, and then signs it.
+func (m *TxManager) resign(
+	from common.Address,
+	signer bind.SignerFn,
+	tx *types.Transaction,
+	gasTipCap *big.Int,
+	gasFeeCap *big.Int,
+) (*types.Transaction, error) {
+	var inner types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: gasFeeCap,
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  gasTipCap,
+			GasFeeCap:  gasFeeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction type: %d", tx.Type())
+	}
+
+	return signer(from, types.NewTx(inner))
+}
+
+// removePending removes all versions of the given pending transaction.
+func (m *TxManager) removePending(pending *pendingTx) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tx := range pending.versions {
+		delete(m.pending, tx.Hash())
+	}
+}
+
+// PendingCount returns the number of transactions sent by this manager which have not been mined yet.
+func (m *TxManager) PendingCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen := make(map[*pendingTx]struct{})
+	for _, pending := range m.pending {
+		seen[pending] = struct{}{}
+	}
+
+	return len(seen)
+}
+
+// isErr checks whether the given error returned by the L1 node is the given transaction pool error,
+// the errors lose their types through RPC calls, so only the messages are compared.
+func isErr(err error, target error) bool {
+	return errors.Is(err, target) || strings.Contains(err.Error(), target.Error())
+}
@@ -0,0 +1,285 @@
+package tx_manager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+	chainID    = big.NewInt(1)
+)
+
+// mockBackend is an in-memory Backend implementation, transactions are only mined in
+// nonce order, when their gas fee cap reaches `minGasFeeCap`.
+type mockBackend struct {
+	mu           sync.Mutex
+	nonce        uint64
+	pooledNonce  uint64 // Nonces below it are used by other transactions in the pool
+	sendErr      error
+	minGasFeeCap *big.Int
+	sent         []*types.Transaction
+	receipts     map[common.Hash]*types.Receipt
+}
+
+func newMockBackend(minGasFeeCap *big.Int) *mockBackend {
+	return &mockBackend{minGasFeeCap: minGasFeeCap, receipts: make(map[common.Hash]*types.Receipt)}
+}
+
+func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pooledNonce > b.nonce {
+		return b.pooledNonce, nil
+	}
+	return b.nonce, nil
+}
+
+func (b *mockBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.nonce, nil
+}
+
+func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.sendErr != nil {
+		return b.sendErr
+	}
+	if tx.Nonce() < b.nonce {
+		return core.ErrNonceTooLow
+	}
+	if tx.Nonce() < b.pooledNonce {
+		return core.ErrReplaceUnderpriced
+	}
+
+	b.sent = append(b.sent, tx)
+	if tx.Nonce() == b.nonce && tx.GasFeeCapIntCmp(b.minGasFeeCap) >= 0 {
+		b.receipts[tx.Hash()] = &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful}
+		b.nonce++
+	}
+
+	return nil
+}
+
+func (b *mockBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func newTestTxManager(backend Backend, maxGasFeeCap *big.Int) *TxManager {
+	return New(backend, func(ctx context.Context) (*bind.TransactOpts, error) {
+		return bind.NewKeyedTransactorWithChainID(testKey, chainID)
+	}, &Config{
+		ResubmissionTimeout:  10 * time.Millisecond,
+		FeeBumpPercentage:    10,
+		MaxGasFeeCap:         maxGasFeeCap,
+		ReceiptQueryInterval: time.Millisecond,
+	})
+}
+
+// buildTx creates a dynamic fee transaction with the given fees and transaction options.
+func buildTx(gasTipCap int64, gasFeeCap int64) func(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     opts.Nonce.Uint64(),
+			GasTipCap: big.NewInt(gasTipCap),
+			GasFeeCap: big.NewInt(gasFeeCap),
+			Gas:       21000,
+			To:        &testAddr,
+		}))
+	}
+}
+
+func TestSendAndWaitReceipt(t *testing.T) {
+	backend := newMockBackend(common.Big0)
+	m := newTestTxManager(backend, nil)
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), tx.Nonce())
+	require.Equal(t, 1, m.PendingCount())
+
+	receipt, err := m.WaitReceipt(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, 0, m.PendingCount())
+
+	// Nonce is tracked locally.
+	tx, err = m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), tx.Nonce())
+}
+
+func TestSendNonceTooLow(t *testing.T) {
+	backend := newMockBackend(common.Big0)
+	m := newTestTxManager(backend, nil)
+
+	_, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+
+	// Nonce used by another transaction not sent by the manager.
+	backend.nonce++
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), tx.Nonce())
+}
+
+func TestSendReplacementUnderpriced(t *testing.T) {
+	backend := newMockBackend(common.Big0)
+	m := newTestTxManager(backend, nil)
+
+	_, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+
+	// Nonce used by another transaction in the L1 node's pool.
+	backend.pooledNonce = 3
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), tx.Nonce())
+}
+
+func TestSendErrorResyncNonce(t *testing.T) {
+	backend := newMockBackend(common.Big0)
+	m := newTestTxManager(backend, nil)
+
+	_, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+	require.NotNil(t, m.nextNonce)
+
+	backend.sendErr = errors.New("connection refused")
+	_, err = m.Send(context.Background(), buildTx(1, 10))
+	require.NotNil(t, err)
+	require.Nil(t, m.nextNonce)
+
+	// Nonce used by another transaction not sent by the manager in the meantime.
+	backend.sendErr = nil
+	backend.nonce++
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), tx.Nonce())
+}
+
+func TestWaitReceiptNonceGap(t *testing.T) {
+	backend := newMockBackend(common.Big0)
+	m := newTestTxManager(backend, nil)
+
+	// The transaction with nonce 0 has been dropped by the L1 node.
+	nonce := uint64(1)
+	m.nextNonce = &nonce
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), tx.Nonce())
+
+	receipt, err := m.WaitReceipt(context.Background(), tx)
+	require.Nil(t, err)
+	require.NotEqual(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, uint64(0), backend.sent[1].Nonce())
+	require.Equal(t, testAddr, *backend.sent[1].To())
+	require.Equal(t, uint64(2), backend.nonce)
+}
+
+func TestWaitReceiptResubmission(t *testing.T) {
+	backend := newMockBackend(big.NewInt(12))
+	m := newTestTxManager(backend, big.NewInt(100))
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+
+	receipt, err := m.WaitReceipt(context.Background(), tx)
+	require.Nil(t, err)
+	require.NotEqual(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, 3, len(backend.sent))
+	require.Equal(t, uint64(12), backend.sent[2].GasFeeCap().Uint64())
+	require.Equal(t, tx.Nonce(), backend.sent[2].Nonce())
+	require.Equal(t, 0, m.PendingCount())
+}
+
+func TestWaitReceiptMaxGasFeeCapReached(t *testing.T) {
+	backend := newMockBackend(big.NewInt(1000))
+	m := newTestTxManager(backend, big.NewInt(20))
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+
+	_, err = m.WaitReceipt(context.Background(), tx)
+	require.ErrorIs(t, err, errMaxGasFeeCapReached)
+	require.Equal(t, uint64(20), backend.sent[len(backend.sent)-1].GasFeeCap().Uint64())
+	require.Equal(t, 0, m.PendingCount())
+}
+
+func TestSendGasFeeCapLimited(t *testing.T) {
+	backend := newMockBackend(common.Big0)
+	m := newTestTxManager(backend, big.NewInt(5))
+
+	tx, err := m.Send(context.Background(), buildTx(8, 10))
+	require.Nil(t, err)
+	require.Equal(t, uint64(5), tx.GasFeeCap().Uint64())
+	require.Equal(t, uint64(5), tx.GasTipCap().Uint64())
+}
+
+func TestWaitReceiptContextCanceled(t *testing.T) {
+	m := newTestTxManager(newMockBackend(big.NewInt(1000)), nil)
+
+	tx, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = m.WaitReceipt(ctx, tx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestReplace(t *testing.T) {
+	backend := newMockBackend(big.NewInt(100))
+	m := newTestTxManager(backend, big.NewInt(1000))
+
+	// Not mined, as if reorged out and back in the L1 node's pool.
+	replaced, err := m.Send(context.Background(), buildTx(1, 10))
+	require.Nil(t, err)
+
+	tx, err := m.Replace(context.Background(), replaced, buildTx(1, 5))
+	require.Nil(t, err)
+	require.Equal(t, replaced.Nonce(), tx.Nonce())
+	require.Equal(t, uint64(11), tx.GasFeeCap().Uint64())
+
+	// Larger fees of the new transaction are kept.
+	tx, err = m.Replace(context.Background(), replaced, buildTx(1, 100))
+	require.Nil(t, err)
+	require.Equal(t, uint64(100), tx.GasFeeCap().Uint64())
+
+	receipt, err := m.WaitReceipt(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+
+	// Nonce used again.
+	_, err = m.Replace(context.Background(), replaced, buildTx(1, 100))
+	require.ErrorIs(t, err, ErrNonceUsed)
+}
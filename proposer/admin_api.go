@@ -0,0 +1,161 @@
+package proposer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/jwt"
+)
+
+// AdminAPI provides the methods to control a running proposer, which are served by
+// the authenticated admin RPC server under the `proposer` namespace.
+type AdminAPI struct {
+	p *Proposer
+}
+
+// InFlightCommit represents a committed but not yet proposed transactions list.
+type InFlightCommit struct {
+	CommitSlot   uint64       `json:"commitSlot"`
+	CommitTxHash *common.Hash `json:"commitTxHash"`
+	TxListHash   common.Hash  `json:"txListHash"`
+	TxListBytes  int          `json:"txListBytes"`
+	TxNum        uint         `json:"txNum"`
+}
+
+// ProposerStatus represents the current runtime status of the proposer.
+type ProposerStatus struct {
+	Paused            bool   `json:"paused"`
+	LowBalancePaused  bool   `json:"lowBalancePaused"`
+	ProposingInterval string `json:"proposingInterval"`
+	InFlightCommits   int    `json:"inFlightCommits"`
+	MaxPendingBlocks  uint64 `json:"maxPendingBlocks"`
+}
+
+// Pause stops the proposer from proposing in the following epochs, the in-flight
+// commits will still be proposed.
+func (api *AdminAPI) Pause() {
+	atomic.StoreInt32(&api.p.paused, 1)
+	log.Info("Proposer paused through admin RPC")
+}
+
+// Resume resumes the paused proposer.
+func (api *AdminAPI) Resume() {
+	atomic.StoreInt32(&api.p.paused, 0)
+	log.Info("Proposer resumed through admin RPC")
+}
+
+// SetProposingInterval changes the proposing interval, e.g. "10s".
+func (api *AdminAPI) SetProposingInterval(interval string) error {
+	duration, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("invalid proposing interval: %w", err)
+	}
+
+	if duration <= 0 {
+		return fmt.Errorf("proposing interval must be positive: %s", interval)
+	}
+
+	select {
+	case api.p.intervalUpdateCh <- duration:
+		return nil
+	case <-api.p.ctx.Done():
+		return api.p.ctx.Err()
+	}
+}
+
+// ProposeNow starts a new proposing epoch immediately, even if the proposer is paused, and
+// returns after the transactions lists are committed. It still fails while proposing is paused
+// because of the low balance.
+func (api *AdminAPI) ProposeNow() error {
+	if api.p.balanceWatchdog.paused() {
+		return errLowBalance
+	}
+
+	errCh := make(chan error, 1)
+
+	select {
+	case api.p.proposeNowCh <- errCh:
+	case <-api.p.ctx.Done():
+		return api.p.ctx.Err()
+	}
+
+	return <-errCh
+}
+
+// InFlightCommits returns all committed but not yet proposed transactions lists.
+func (api *AdminAPI) InFlightCommits() []*InFlightCommit {
+	var inFlightCommits []*InFlightCommit
+	for _, commitRes := range api.p.getInFlightCommits() {
+		inFlightCommit := &InFlightCommit{
+			CommitSlot:  commitRes.meta.CommitSlot,
+			TxListHash:  commitRes.meta.TxListHash,
+			TxListBytes: len(commitRes.txListBytes),
+			TxNum:       commitRes.txNum,
+		}
+
+		if commitRes.commitTx != nil {
+			hash := commitRes.commitTx.Hash()
+			inFlightCommit.CommitTxHash = &hash
+		}
+
+		inFlightCommits = append(inFlightCommits, inFlightCommit)
+	}
+
+	return inFlightCommits
+}
+
+// Status returns the current runtime status of the proposer.
+func (api *AdminAPI) Status() *ProposerStatus {
+	return &ProposerStatus{
+		Paused:            atomic.LoadInt32(&api.p.paused) != 0,
+		LowBalancePaused:  api.p.balanceWatchdog.paused(),
+		ProposingInterval: time.Duration(atomic.LoadInt64((*int64)(&api.p.proposingInterval))).String(),
+		InFlightCommits:   len(api.p.getInFlightCommits()),
+		MaxPendingBlocks:  api.p.maxPendingBlocks,
+	}
+}
+
+// startAdminRPCServer starts the admin RPC server, all requests must be authenticated with
+// a JWT token signed by the configured secret, the server will be closed when the proposer's
+// context is canceled.
+func (p *Proposer) startAdminRPCServer() error {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("proposer", &AdminAPI{p}); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", p.adminRPCAddr)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           jwt.NewHandler(p.adminJWTSecret, srv),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-p.ctx.Done()
+		if err := server.Close(); err != nil {
+			log.Error("Failed to close admin RPC server", "error", err)
+		}
+		srv.Stop()
+	}()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Admin RPC server error", "error", err)
+		}
+	}()
+
+	log.Info("Admin RPC server started", "address", listener.Addr())
+
+	return nil
+}
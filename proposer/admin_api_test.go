@@ -0,0 +1,73 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+func (s *ProposerTestSuite) TestAdminAPI() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		commitTx   = types.NewTx(&types.LegacyTx{})
+		txListHash = common.BytesToHash(testutils.RandomBytes(32))
+	)
+
+	p := &Proposer{
+		ctx:               ctx,
+		proposingInterval: time.Minute,
+		maxPendingBlocks:  2,
+		intervalUpdateCh:  make(chan time.Duration, 1),
+		balanceWatchdog:   newBalanceWatchdog(nil, 0, true),
+		inFlightCommits: []*commitTxListRes{{
+			meta:        &bindings.LibDataBlockMetadata{CommitSlot: 1, TxListHash: txListHash},
+			commitTx:    commitTx,
+			txListBytes: []byte{0x01, 0x02},
+			txNum:       1,
+		}},
+	}
+	api := &AdminAPI{p}
+
+	api.Pause()
+	s.True(api.Status().Paused)
+	api.Resume()
+	s.False(api.Status().Paused)
+
+	s.NotNil(api.SetProposingInterval("invalid"))
+	s.NotNil(api.SetProposingInterval("-1s"))
+	s.Nil(api.SetProposingInterval("10s"))
+	s.Equal(10*time.Second, <-p.intervalUpdateCh)
+
+	status := api.Status()
+	s.Equal(time.Minute.String(), status.ProposingInterval)
+	s.Equal(1, status.InFlightCommits)
+	s.Equal(uint64(2), status.MaxPendingBlocks)
+	s.False(status.LowBalancePaused)
+
+	inFlightCommits := api.InFlightCommits()
+	s.Len(inFlightCommits, 1)
+	s.Equal(uint64(1), inFlightCommits[0].CommitSlot)
+	s.Equal(commitTx.Hash(), *inFlightCommits[0].CommitTxHash)
+	s.Equal(txListHash, inFlightCommits[0].TxListHash)
+	s.Equal(2, inFlightCommits[0].TxListBytes)
+
+	p.removeInFlightCommit(p.inFlightCommits[0])
+	s.Empty(api.InFlightCommits())
+
+	// Paused because of the low balance.
+	p.balanceWatchdog = newBalanceWatchdog(big.NewInt(100), 0, true)
+	p.balanceWatchdog.update(common.Big1)
+	s.ErrorIs(api.ProposeNow(), errLowBalance)
+	p.balanceWatchdog.update(big.NewInt(100))
+
+	// Canceled proposer context.
+	cancel()
+	s.NotNil(api.ProposeNow())
+}
@@ -0,0 +1,139 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// errLowBalance is returned when proposing is paused because of the low balance.
+var errLowBalance = errors.New("L1 proposer balance is low, proposing is paused")
+
+// balanceWatchdog tracks the L1 balance of the proposer account, and estimates how many proposing
+// epochs it can still fund, based on the average balance spent per epoch which proposed blocks.
+// TaikoL1 doesn't charge the proposers any token fee in the current protocol version, so there
+// is no token allowance to watch besides the balance.
+type balanceWatchdog struct {
+	warnBalance *big.Int // Warn if the balance is below this value, nil or zero to disable
+	warnEpochs  uint64   // Warn if the funded epochs are below this value, zero to disable
+	pauseOnLow  bool     // Pause proposing while the balance is low
+
+	mu             sync.RWMutex
+	lastBalance    *big.Int
+	epochCost      *big.Int // Moving average of the balance spent per proposing epoch
+	proposed       bool     // Whether the current epoch has sent any L1 transaction
+	proposedEpochs uint64   // Proposing epochs whose spending has not been sampled yet
+	low            bool
+}
+
+// newBalanceWatchdog creates a new balanceWatchdog instance.
+func newBalanceWatchdog(warnBalance *big.Int, warnEpochs uint64, pauseOnLow bool) *balanceWatchdog {
+	return &balanceWatchdog{
+		warnBalance: warnBalance,
+		warnEpochs:  warnEpochs,
+		pauseOnLow:  pauseOnLow,
+		epochCost:   new(big.Int),
+	}
+}
+
+// onProposed marks the current epoch as a proposing one, it should be called once an L1 transaction
+// is sent by the proposer.
+func (w *balanceWatchdog) onProposed() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.proposed = true
+}
+
+// update records the balance at the beginning of a new proposing epoch, and returns the estimated
+// funded epochs (false if not known yet) and whether the balance is low.
+func (w *balanceWatchdog) update(balance *big.Int) (uint64, bool, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.proposed {
+		w.proposedEpochs++
+		w.proposed = false
+	}
+
+	// The idle epochs spend nothing, so only the proposing epochs are sampled, once their transactions
+	// are mined and the balance drops. A deposit might hide the spending, in that case the proposing
+	// epochs are not sampled.
+	if w.lastBalance != nil && w.lastBalance.Cmp(balance) < 0 {
+		w.proposedEpochs = 0
+	}
+
+	if w.lastBalance != nil && w.proposedEpochs != 0 && w.lastBalance.Cmp(balance) > 0 {
+		spent := new(big.Int).Sub(w.lastBalance, balance)
+		spent.Quo(spent, new(big.Int).SetUint64(w.proposedEpochs))
+		w.proposedEpochs = 0
+
+		if w.epochCost.Sign() == 0 {
+			w.epochCost.Set(spent)
+		} else {
+			// epochCost += (spent - epochCost) / 8
+			w.epochCost.Add(w.epochCost, new(big.Int).Quo(new(big.Int).Sub(spent, w.epochCost), big.NewInt(8)))
+		}
+	}
+	w.lastBalance = new(big.Int).Set(balance)
+
+	var (
+		fundedEpochs uint64
+		known        = w.epochCost.Sign() > 0
+	)
+	if known {
+		if epochs := new(big.Int).Div(balance, w.epochCost); epochs.IsUint64() {
+			fundedEpochs = epochs.Uint64()
+		}
+	}
+
+	w.low = (w.warnBalance != nil && balance.Cmp(w.warnBalance) < 0) ||
+		(known && fundedEpochs < w.warnEpochs)
+
+	return fundedEpochs, known, w.low
+}
+
+// paused returns whether proposing should be paused because of the low balance.
+func (w *balanceWatchdog) paused() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.pauseOnLow && w.low
+}
+
+// checkBalanceOp fetches the L1 balance of the proposer account, updates the watchdog
+// and the metrics, and warns if the balance is low.
+func (p *Proposer) checkBalanceOp(ctx context.Context) error {
+	balance, err := p.rpc.L1.BalanceAt(ctx, p.l1ProposerSigner.Address(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 proposer balance: %w", err)
+	}
+
+	fundedEpochs, known, low := p.balanceWatchdog.update(balance)
+
+	metrics.ProposerBalanceGauge.Update(new(big.Int).Div(balance, big.NewInt(params.GWei)).Int64())
+	if known {
+		metrics.ProposerFundedEpochsGauge.Update(int64(fundedEpochs))
+	}
+
+	if !low {
+		metrics.ProposerLowBalanceGauge.Update(0)
+		return nil
+	}
+
+	metrics.ProposerLowBalanceGauge.Update(1)
+
+	logCtx := []interface{}{"address", p.l1ProposerSigner.Address(), "balance", balance}
+	if known {
+		logCtx = append(logCtx, "fundedEpochs", fundedEpochs)
+	}
+	log.Warn("L1 proposer balance is low", append(logCtx, "pause", p.balanceWatchdog.pauseOnLow)...)
+
+	return nil
+}
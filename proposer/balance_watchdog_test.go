@@ -0,0 +1,66 @@
+package proposer
+
+import (
+	"math/big"
+)
+
+func (s *ProposerTestSuite) TestBalanceWatchdog() {
+	w := newBalanceWatchdog(big.NewInt(100), 5, true)
+
+	// No spending epoch sampled yet.
+	_, known, low := w.update(big.NewInt(1000))
+	s.False(known)
+	s.False(low)
+	s.False(w.paused())
+
+	// 80 spent in the first proposing epoch.
+	w.onProposed()
+	fundedEpochs, known, low := w.update(big.NewInt(920))
+	s.True(known)
+	s.Equal(uint64(11), fundedEpochs)
+	s.False(low)
+
+	// A deposit is not sampled.
+	w.onProposed()
+	fundedEpochs, known, low = w.update(big.NewInt(1600))
+	s.True(known)
+	s.Equal(uint64(20), fundedEpochs)
+	s.False(low)
+
+	// An idle epoch is not sampled.
+	fundedEpochs, _, _ = w.update(big.NewInt(1600))
+	s.Equal(uint64(20), fundedEpochs)
+
+	// 240 spent by a proposing epoch, once mined in the next epoch, the average becomes
+	// 80 + (240 - 80) / 8 = 100.
+	w.onProposed()
+	fundedEpochs, _, _ = w.update(big.NewInt(1600))
+	s.Equal(uint64(20), fundedEpochs)
+	fundedEpochs, _, low = w.update(big.NewInt(1360))
+	s.Equal(uint64(13), fundedEpochs)
+	s.False(low)
+
+	// Spending of two proposing epochs, 100 per epoch.
+	w.onProposed()
+	w.update(big.NewInt(1360))
+	w.onProposed()
+	fundedEpochs, _, _ = w.update(big.NewInt(1160))
+	s.Equal(uint64(11), fundedEpochs)
+
+	// Below the funded epochs threshold.
+	w.onProposed()
+	fundedEpochs, _, low = w.update(big.NewInt(400))
+	s.Less(fundedEpochs, uint64(5))
+	s.True(low)
+	s.True(w.paused())
+
+	// Below the balance threshold.
+	w = newBalanceWatchdog(big.NewInt(100), 0, false)
+	_, _, low = w.update(big.NewInt(99))
+	s.True(low)
+	s.False(w.paused())
+
+	// Topped up.
+	_, _, low = w.update(big.NewInt(100))
+	s.False(low)
+}
@@ -0,0 +1,257 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// commitSlotAllocator hands out the commit slots used by TaikoL1.commitBlock transactions. It tracks
+// which slots have outstanding commitments, including the ones committed by other proposer processes
+// using the same L1 account, so a pending commitment will never be overwritten.
+type commitSlotAllocator struct {
+	baseSlot      uint64         // Slots are allocated starting from this one
+	proposer      common.Address // Commitments are stored per L1 account in TaikoL1
+	confirmations uint64         // TaikoL1's commitDelayConfirmations
+	expiry        uint64         // Number of L1 blocks after which an outstanding commitment is considered expired
+
+	mu               sync.Mutex
+	occupied         map[uint64]*slotOccupation
+	unconfirmed      []*bindings.TaikoL1ClientBlockCommitted // Other commitments waiting for confirmations
+	lastSyncedHeight uint64
+	ownTxs           map[common.Hash]uint64 // Own commit transactions, to the synced L1 height when sent
+	classified       map[common.Hash]uint64 // Commit transactions whose events are handled, to their heights
+}
+
+// slotOccupation represents an outstanding commitment in a commit slot.
+type slotOccupation struct {
+	own          bool        // Whether the slot is allocated by this proposer process
+	commitHash   common.Hash // Only used by the commitments of this proposer process
+	commitHeight uint64      // Only used by the commitments of other proposer processes
+}
+
+// newCommitSlotAllocator creates a new commitSlotAllocator instance.
+func newCommitSlotAllocator(
+	baseSlot uint64,
+	proposer common.Address,
+	confirmations uint64,
+	expiry uint64,
+) *commitSlotAllocator {
+	return &commitSlotAllocator{
+		baseSlot:      baseSlot,
+		proposer:      proposer,
+		confirmations: confirmations,
+		expiry:        expiry,
+		occupied:      make(map[uint64]*slotOccupation),
+		ownTxs:        make(map[common.Hash]uint64),
+		classified:    make(map[common.Hash]uint64),
+	}
+}
+
+// allocate returns the first free commit slot starting from the base slot for the given
+// commit hash, and marks it as occupied until it is released.
+func (a *commitSlotAllocator) allocate(commitHash common.Hash) uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	slot := a.baseSlot
+	for {
+		if _, ok := a.occupied[slot]; !ok {
+			break
+		}
+		slot++
+	}
+
+	a.occupied[slot] = &slotOccupation{own: true, commitHash: commitHash}
+
+	return slot
+}
+
+// release reclaims the given commit slot allocated by this proposer process, after its block
+// has been proposed, or its commitment has been abandoned.
+func (a *commitSlotAllocator) release(slot uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if occupation, ok := a.occupied[slot]; ok && occupation.own {
+		delete(a.occupied, slot)
+	}
+}
+
+// recordCommitTx records the hash of a TaikoL1.commitBlock transaction sent by this proposer process,
+// so its BlockCommitted event is never taken as another proposer process's commitment, even after
+// the commit slot has been released.
+func (a *commitSlotAllocator) recordCommitTx(txHash common.Hash) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.ownTxs[txHash] = a.lastSyncedHeight
+}
+
+// sync fetches the new BlockCommitted events from L1, to find the outstanding commitments made by
+// other proposer processes using the same L1 account, and reclaims the expired ones.
+func (a *commitSlotAllocator) sync(ctx context.Context, cli *rpc.Client) error {
+	l1Head, err := cli.L1.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	start := a.lastSyncedHeight + 1
+	if a.lastSyncedHeight == 0 && l1Head > a.expiry {
+		start = l1Head - a.expiry
+	}
+
+	if start <= l1Head {
+		iter, err := cli.TaikoL1.FilterBlockCommitted(&bind.FilterOpts{Start: start, End: &l1Head, Context: ctx})
+		if err != nil {
+			return fmt.Errorf("failed to filter BlockCommitted events: %w", err)
+		}
+		defer iter.Close()
+
+		for iter.Next() {
+			event := iter.Event
+			if event.Raw.Removed {
+				continue
+			}
+
+			// Each event is only classified once, even if a previous sync failed halfway.
+			if _, ok := a.classified[event.Raw.TxHash]; ok {
+				continue
+			}
+
+			// Committed by this proposer process, the re-broadcasted versions of the commit transactions
+			// are only recorded after being mined, but their slots are still allocated before that.
+			if _, ok := a.ownTxs[event.Raw.TxHash]; ok {
+				delete(a.ownTxs, event.Raw.TxHash)
+				a.classified[event.Raw.TxHash] = event.CommitHeight
+				continue
+			}
+			if occupation, ok := a.occupied[event.CommitSlot]; ok && occupation.own &&
+				occupation.commitHash == event.CommitHash {
+				a.classified[event.Raw.TxHash] = event.CommitHeight
+				continue
+			}
+
+			// Commitments are stored per L1 account, so only the ones committed by the same L1
+			// account can occupy the commit slots.
+			sender, err := a.commitSender(ctx, cli, event)
+			if err != nil {
+				return err
+			}
+			a.classified[event.Raw.TxHash] = event.CommitHeight
+			if sender != a.proposer {
+				continue
+			}
+
+			a.unconfirmed = append(a.unconfirmed, event)
+		}
+
+		if err := iter.Error(); err != nil {
+			return fmt.Errorf("failed to iterate BlockCommitted events: %w", err)
+		}
+
+		a.lastSyncedHeight = l1Head
+	}
+
+	// Only after the confirmations, we can know whether a commitment is still valid through
+	// TaikoL1.isCommitValid, before that, the commit slot is considered as occupied.
+	var unconfirmed []*bindings.TaikoL1ClientBlockCommitted
+	for i, event := range a.unconfirmed {
+		if event.CommitHeight+a.expiry < l1Head {
+			continue
+		}
+
+		if event.CommitHeight+a.confirmations > l1Head {
+			a.occupyExternal(event.CommitSlot, event.CommitHeight)
+			unconfirmed = append(unconfirmed, event)
+			continue
+		}
+
+		isValid, err := cli.TaikoL1.IsCommitValid(
+			&bind.CallOpts{From: a.proposer, Context: ctx},
+			new(big.Int).SetUint64(event.CommitSlot),
+			new(big.Int).SetUint64(event.CommitHeight),
+			event.CommitHash,
+		)
+		if err != nil {
+			a.unconfirmed = append(unconfirmed, a.unconfirmed[i:]...)
+			return fmt.Errorf("failed to check commitment validity: %w", err)
+		}
+
+		if !isValid {
+			if occupation, ok := a.occupied[event.CommitSlot]; ok && !occupation.own {
+				delete(a.occupied, event.CommitSlot)
+			}
+			continue
+		}
+
+		log.Info(
+			"Commit slot occupied by another proposer process",
+			"slot", event.CommitSlot,
+			"commitHeight", event.CommitHeight,
+		)
+		a.occupyExternal(event.CommitSlot, event.CommitHeight)
+	}
+	a.unconfirmed = unconfirmed
+
+	// Reclaim the expired commitments.
+	for slot, occupation := range a.occupied {
+		if !occupation.own && occupation.commitHeight+a.expiry < l1Head {
+			delete(a.occupied, slot)
+		}
+	}
+	for txHash, height := range a.classified {
+		if height+a.expiry < l1Head {
+			delete(a.classified, txHash)
+		}
+	}
+	for txHash, height := range a.ownTxs {
+		if height+a.expiry < l1Head {
+			delete(a.ownTxs, txHash)
+		}
+	}
+
+	return nil
+}
+
+// commitSender returns the sender of the TaikoL1.commitBlock transaction of the given event.
+func (a *commitSlotAllocator) commitSender(
+	ctx context.Context,
+	cli *rpc.Client,
+	event *bindings.TaikoL1ClientBlockCommitted,
+) (common.Address, error) {
+	tx, err := cli.L1.TransactionInBlock(ctx, event.Raw.BlockHash, event.Raw.TxIndex)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch commit transaction: %w", err)
+	}
+
+	sender, err := cli.L1.TransactionSender(ctx, tx, event.Raw.BlockHash, event.Raw.TxIndex)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch commit transaction sender: %w", err)
+	}
+
+	return sender, nil
+}
+
+// occupyExternal marks the given commit slot as occupied by another proposer process, if
+// it is not allocated by this proposer process.
+func (a *commitSlotAllocator) occupyExternal(slot uint64, commitHeight uint64) {
+	if occupation, ok := a.occupied[slot]; ok {
+		if !occupation.own && occupation.commitHeight < commitHeight {
+			occupation.commitHeight = commitHeight
+		}
+		return
+	}
+
+	a.occupied[slot] = &slotOccupation{commitHeight: commitHeight}
+}
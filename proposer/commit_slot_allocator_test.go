@@ -0,0 +1,46 @@
+package proposer
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func (s *ProposerTestSuite) TestCommitSlotAllocator() {
+	allocator := newCommitSlotAllocator(10, common.Address{}, 1, 100)
+
+	s.Equal(uint64(10), allocator.allocate(common.Hash{}))
+	s.Equal(uint64(11), allocator.allocate(common.Hash{}))
+
+	// Slot occupied by another proposer process is skipped.
+	allocator.occupyExternal(12, 1)
+	s.Equal(uint64(13), allocator.allocate(common.Hash{}))
+
+	// Released slot is reused.
+	allocator.release(10)
+	s.Equal(uint64(10), allocator.allocate(common.Hash{}))
+
+	// Slot occupied by another proposer process can't be released.
+	allocator.release(12)
+	s.Equal(uint64(14), allocator.allocate(common.Hash{}))
+
+	// Slot allocated by this proposer process is not taken over.
+	allocator.occupyExternal(11, 1)
+	s.True(allocator.occupied[11].own)
+
+	// Commit hash of the allocated slot is recorded.
+	commitHash := common.BytesToHash([]byte{0x01})
+	s.Equal(uint64(15), allocator.allocate(commitHash))
+	s.Equal(commitHash, allocator.occupied[15].commitHash)
+
+	// Own commit transactions are recorded with the synced L1 height.
+	allocator.lastSyncedHeight = 20
+	txHash := common.BytesToHash([]byte{0x02})
+	allocator.recordCommitTx(txHash)
+	s.Equal(map[common.Hash]uint64{txHash: 20}, allocator.ownTxs)
+}
+
+func (s *ProposerTestSuite) TestCommitSlotAllocatorOverflow() {
+	allocator := newCommitSlotAllocator(^uint64(0), common.Address{}, 1, 100)
+
+	s.Equal(^uint64(0), allocator.allocate(common.Hash{}))
+	s.Equal(uint64(0), allocator.allocate(common.Hash{}))
+}
@@ -1,41 +1,83 @@
 package proposer
 
 import (
-	"crypto/ecdsa"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
+	feeStrategy "github.com/taikoxyz/taiko-client/pkg/fee_strategy"
+	"github.com/taikoxyz/taiko-client/pkg/jwt"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	"github.com/urfave/cli/v2"
 )
 
 // Config contains all configurations to initialize a Taiko proposer.
 type Config struct {
-	L1Endpoint              string
-	L2Endpoint              string
-	TaikoL1Address          common.Address
-	TaikoL2Address          common.Address
-	L1ProposerPrivKey       *ecdsa.PrivateKey
-	L2SuggestedFeeRecipient common.Address
-	ProposeInterval         time.Duration
-	ShufflePoolContent      bool
-	CommitSlot              uint64
+	L1Endpoint               string
+	L2Endpoint               string
+	TaikoL1Address           common.Address
+	TaikoL2Address           common.Address
+	L1ProposerSigner         signer.Signer
+	L2SuggestedFeeRecipients []*FeeRecipient
+	FeeRecipientRotation     string
+	ProposeInterval          time.Duration
+	ShufflePoolContent       bool
+	TxOrderingStrategy       string
+	TxSource                 string
+	SenderListsFile          string
+	TxListCodec              byte
+	CompressedTxLists        bool
+	PrecheckTxLists          bool
+	BlockTargetGas           uint64
+	BlockMaxTxs              uint64
+	BlockMaxBytes            uint64
+	BlockMinFillPercentage   uint64
+	BlockMaxDeferredEpochs   uint64
+	CommitSlot               uint64
+	CommitSlotExpiry         uint64
+	CheckProfitability       bool
+	MinProfitMargin          float64
+	MaxSkippedEpochs         uint64
+	L2GasUsedDiscount        float64
+	CommitBlockExecutionGas  uint64
+	ProposeBlockExecutionGas uint64
+	TxResubmissionTimeout    time.Duration
+	TxFeeBumpPercentage      uint64
+	FeeStrategy              *feeStrategy.Config
+	TriggerGasThreshold      uint64
+	TriggerBytesThreshold    uint64
+	TriggerMinGas            uint64
+	TriggerMaxSkippedTicks   uint64
+	ReorgConfirmations       uint64
+	BalanceWarnThreshold     *big.Int
+	BalanceWarnEpochs        uint64
+	PauseOnLowBalance        bool
+	DryRun                   bool
+	AdminRPCAddr             string
+	AdminJWTSecret           []byte
 
 	// Only for testing
-	ProduceInvalidBlocks         bool
-	ProduceInvalidBlocksInterval uint64
+	FaultInjectionConfigFile string
+	InvalidBlocksInterval    uint64 // Deprecated produceInvalidBlocks flags, 0 if not activated
 }
 
 // NewConfigFromCliContext initializes a Config instance from
 // command line flags.
 func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
-	l1ProposerPrivKey, err := crypto.ToECDSA(
-		common.Hex2Bytes(c.String(flags.L1ProposerPrivKey.Name)),
-	)
+	l1ProposerSigner, err := signer.New(c.Context, &signer.Config{
+		PrivKey:              c.String(flags.L1ProposerPrivKey.Name),
+		KeystorePath:         c.String(flags.L1ProposerKeystore.Name),
+		KeystorePasswordFile: c.String(flags.L1ProposerKeystorePassword.Name),
+		RemoteSignerEndpoint: c.String(flags.L1ProposerRemoteSigner.Name),
+		RemoteSignerAddress:  c.String(flags.L1ProposerAddress.Name),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid L1 proposer private key: %w", err)
+		return nil, fmt.Errorf("invalid L1 proposer signer: %w", err)
 	}
 
 	// Proposing configuration
@@ -44,22 +86,200 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		return nil, fmt.Errorf("invalid proposing interval: %w", err)
 	}
 
-	l2SuggestedFeeRecipient := c.String(flags.L2SuggestedFeeRecipient.Name)
-	if !common.IsHexAddress(l2SuggestedFeeRecipient) {
-		return nil, fmt.Errorf("invalid L2 suggested fee recipient address: %s", l2SuggestedFeeRecipient)
+	l2SuggestedFeeRecipients, err := parseFeeRecipients(c.String(flags.L2SuggestedFeeRecipient.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	feeRecipientRotation := c.String(flags.FeeRecipientRotation.Name)
+	if _, err := newFeeRecipientSelector(feeRecipientRotation, l2SuggestedFeeRecipients); err != nil {
+		return nil, err
+	}
+
+	txOrderingStrategy := c.String(flags.TxOrderingStrategy.Name)
+	if _, err := NewTxOrderingStrategy(txOrderingStrategy); err != nil {
+		return nil, err
+	}
+
+	txSource := c.String(flags.TxSource.Name)
+	switch txSource {
+	case "", TxSourcePolling, TxSourceStreaming:
+	default:
+		return nil, fmt.Errorf("unknown transactions source: %s", txSource)
+	}
+
+	txListCodec, err := encoding.ParseTxListCodec(c.String(flags.TxListCompression.Name))
+	if err != nil {
+		return nil, err
+	}
+	if txListCodec != encoding.TxListCodecNone && !c.Bool(flags.CompressedTxLists.Name) {
+		return nil, fmt.Errorf("%s requires %s to be enabled", flags.TxListCompression.Name, flags.CompressedTxLists.Name)
+	}
+
+	blockMinFillPercentage := c.Uint64(flags.BlockMinFillPercentage.Name)
+	if blockMinFillPercentage > 100 {
+		return nil, fmt.Errorf("block minimum fill percentage must be at most 100: %d", blockMinFillPercentage)
+	}
+
+	minProfitMargin := c.Float64(flags.MinProfitMargin.Name)
+	if minProfitMargin <= -1 {
+		return nil, fmt.Errorf("invalid minimum profit margin: %v", minProfitMargin)
+	}
+
+	l2GasUsedDiscount := c.Float64(flags.L2GasUsedDiscount.Name)
+	if l2GasUsedDiscount < 0 || l2GasUsedDiscount >= 1 {
+		return nil, fmt.Errorf("invalid L2 gas used discount: %v", l2GasUsedDiscount)
+	}
+
+	txResubmissionTimeout, err := time.ParseDuration(c.String(flags.TxResubmissionTimeout.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction resubmission timeout: %w", err)
+	}
+
+	txFeeBumpPercentage := c.Uint64(flags.TxFeeBumpPercentage.Name)
+	if txFeeBumpPercentage < 10 {
+		return nil, fmt.Errorf("transaction fee bump percentage must be at least 10: %d", txFeeBumpPercentage)
+	}
+
+	var maxGasFeeCap *big.Int
+	if s := c.String(flags.FeeMaxGasFeeCap.Name); s != "" {
+		var ok bool
+		if maxGasFeeCap, ok = new(big.Int).SetString(s, 10); !ok {
+			return nil, fmt.Errorf("invalid max gas fee cap: %s", s)
+		}
+	}
+
+	feeStrategyConfig := &feeStrategy.Config{
+		BaseFeeMultiplier: c.Float64(flags.FeeBaseFeeMultiplier.Name),
+		TipPercentile:     c.Float64(flags.FeeTipPercentile.Name),
+		MaxGasFeeCap:      maxGasFeeCap,
+		Legacy:            c.Bool(flags.FeeLegacy.Name),
+	}
+	if err := feeStrategyConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fee strategy: %w", err)
+	}
+
+	balanceWarnThreshold := new(big.Int)
+	if s := c.String(flags.BalanceWarnThreshold.Name); s != "" {
+		if _, ok := balanceWarnThreshold.SetString(s, 10); !ok || balanceWarnThreshold.Sign() < 0 {
+			return nil, fmt.Errorf("invalid balance warning threshold: %s", s)
+		}
+	}
+
+	var adminJWTSecret []byte
+	if c.String(flags.AdminRPCAddr.Name) != "" {
+		if adminJWTSecret, err = jwt.ParseSecretFromFile(c.String(flags.AdminJWTSecret.Name)); err != nil {
+			return nil, fmt.Errorf("invalid admin RPC JWT secret file: %w", err)
+		}
+
+		if len(adminJWTSecret) == 0 {
+			return nil, fmt.Errorf("admin RPC JWT secret file is required when admin RPC server is enabled")
+		}
+	}
+
+	// The deprecated produceInvalidBlocks flags are mapped to the fault injection scenarios.
+	var invalidBlocksInterval uint64
+	if c.Bool(flags.ProduceInvalidBlocks.Name) {
+		invalidBlocksInterval = c.Uint64(flags.ProduceInvalidBlocksInterval.Name)
 	}
 
 	return &Config{
-		L1Endpoint:                   c.String(flags.L1NodeEndpoint.Name),
-		L2Endpoint:                   c.String(flags.L2NodeEndpoint.Name),
-		TaikoL1Address:               common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
-		TaikoL2Address:               common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
-		L1ProposerPrivKey:            l1ProposerPrivKey,
-		L2SuggestedFeeRecipient:      common.HexToAddress(l2SuggestedFeeRecipient),
-		ProposeInterval:              proposingInterval,
-		ShufflePoolContent:           c.Bool(flags.ShufflePoolContent.Name),
-		CommitSlot:                   c.Uint64(flags.CommitSlot.Name),
-		ProduceInvalidBlocks:         c.Bool(flags.ProduceInvalidBlocks.Name),
-		ProduceInvalidBlocksInterval: c.Uint64(flags.ProduceInvalidBlocksInterval.Name),
+		L1Endpoint:               c.String(flags.L1NodeEndpoint.Name),
+		L2Endpoint:               c.String(flags.L2NodeEndpoint.Name),
+		TaikoL1Address:           common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+		TaikoL2Address:           common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+		L1ProposerSigner:         l1ProposerSigner,
+		L2SuggestedFeeRecipients: l2SuggestedFeeRecipients,
+		FeeRecipientRotation:     feeRecipientRotation,
+		ProposeInterval:          proposingInterval,
+		ShufflePoolContent:       c.Bool(flags.ShufflePoolContent.Name),
+		TxOrderingStrategy:       txOrderingStrategy,
+		TxSource:                 txSource,
+		SenderListsFile:          c.String(flags.SenderListsFile.Name),
+		TxListCodec:              txListCodec,
+		CompressedTxLists:        c.Bool(flags.CompressedTxLists.Name),
+		PrecheckTxLists:          c.Bool(flags.PrecheckTxLists.Name),
+		BlockTargetGas:           c.Uint64(flags.BlockTargetGas.Name),
+		BlockMaxTxs:              c.Uint64(flags.BlockMaxTxs.Name),
+		BlockMaxBytes:            c.Uint64(flags.BlockMaxBytes.Name),
+		BlockMinFillPercentage:   blockMinFillPercentage,
+		BlockMaxDeferredEpochs:   c.Uint64(flags.BlockMaxDeferredEpochs.Name),
+		CommitSlot:               c.Uint64(flags.CommitSlot.Name),
+		CommitSlotExpiry:         c.Uint64(flags.CommitSlotExpiry.Name),
+		CheckProfitability:       c.Bool(flags.CheckProfitability.Name),
+		MinProfitMargin:          minProfitMargin,
+		MaxSkippedEpochs:         c.Uint64(flags.MaxSkippedEpochs.Name),
+		L2GasUsedDiscount:        l2GasUsedDiscount,
+		CommitBlockExecutionGas:  c.Uint64(flags.CommitBlockExecutionGas.Name),
+		ProposeBlockExecutionGas: c.Uint64(flags.ProposeBlockExecutionGas.Name),
+		TxResubmissionTimeout:    txResubmissionTimeout,
+		TxFeeBumpPercentage:      txFeeBumpPercentage,
+		FeeStrategy:              feeStrategyConfig,
+		TriggerGasThreshold:      c.Uint64(flags.TriggerGasThreshold.Name),
+		TriggerBytesThreshold:    c.Uint64(flags.TriggerBytesThreshold.Name),
+		TriggerMinGas:            c.Uint64(flags.TriggerMinGas.Name),
+		TriggerMaxSkippedTicks:   c.Uint64(flags.TriggerMaxSkippedTicks.Name),
+		ReorgConfirmations:       c.Uint64(flags.ReorgConfirmations.Name),
+		BalanceWarnThreshold:     balanceWarnThreshold,
+		BalanceWarnEpochs:        c.Uint64(flags.BalanceWarnEpochs.Name),
+		PauseOnLowBalance:        c.Bool(flags.PauseOnLowBalance.Name),
+		DryRun:                   c.Bool(flags.DryRun.Name),
+		AdminRPCAddr:             c.String(flags.AdminRPCAddr.Name),
+		AdminJWTSecret:           adminJWTSecret,
+		FaultInjectionConfigFile: c.String(flags.FaultInjectionConfig.Name),
+		InvalidBlocksInterval:    invalidBlocksInterval,
 	}, nil
 }
+
+// validateBlockLimits checks that the operator block limits never exceed the given protocol limits
+// fetched from TaikoL1 contract.
+func (c *Config) validateBlockLimits(maxGasPerBlock, maxTxPerBlock, maxTxBytesPerBlock uint64) error {
+	if c.BlockTargetGas > maxGasPerBlock {
+		return fmt.Errorf(
+			"block target gas exceeds the protocol maxGasPerBlock, got=%d, limit=%d", c.BlockTargetGas, maxGasPerBlock,
+		)
+	}
+
+	if c.BlockMaxTxs > maxTxPerBlock {
+		return fmt.Errorf(
+			"block max transactions exceeds the protocol maxTxPerBlock, got=%d, limit=%d", c.BlockMaxTxs, maxTxPerBlock,
+		)
+	}
+
+	if c.BlockMaxBytes > maxTxBytesPerBlock {
+		return fmt.Errorf(
+			"block max bytes exceeds the protocol maxTxBytesPerBlock, got=%d, limit=%d", c.BlockMaxBytes, maxTxBytesPerBlock,
+		)
+	}
+
+	return nil
+}
+
+// parseFeeRecipients parses the comma separated L2 suggested fee recipients, each recipient
+// can be followed by its weight, e.g. "0x1234...:3", the default weight is 1.
+func parseFeeRecipients(s string) ([]*FeeRecipient, error) {
+	var recipients []*FeeRecipient
+	for _, item := range strings.Split(s, ",") {
+		var (
+			parts     = strings.SplitN(strings.TrimSpace(item), ":", 2)
+			recipient = &FeeRecipient{Weight: 1}
+		)
+
+		if !common.IsHexAddress(parts[0]) {
+			return nil, fmt.Errorf("invalid L2 suggested fee recipient address: %s", parts[0])
+		}
+		recipient.Address = common.HexToAddress(parts[0])
+
+		if len(parts) == 2 {
+			weight, err := strconv.ParseUint(parts[1], 10, 64)
+			if err != nil || weight == 0 {
+				return nil, fmt.Errorf("invalid L2 suggested fee recipient weight: %s", item)
+			}
+			recipient.Weight = weight
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, nil
+}
@@ -5,9 +5,10 @@ import (
 	"os"
 	"strconv"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-client/testutils"
 	"github.com/urfave/cli/v2"
 )
 
@@ -27,8 +28,22 @@ func (s *ProposerTestSuite) TestNewConfigFromCliContext() {
 		&cli.StringFlag{Name: flags.TaikoL2Address.Name},
 		&cli.StringFlag{Name: flags.L1ProposerPrivKey.Name},
 		&cli.StringFlag{Name: flags.L2SuggestedFeeRecipient.Name},
+		&cli.StringFlag{Name: flags.FeeRecipientRotation.Name},
 		&cli.StringFlag{Name: flags.ProposeInterval.Name},
 		&cli.Uint64Flag{Name: flags.CommitSlot.Name},
+		&cli.StringFlag{Name: flags.TxOrderingStrategy.Name},
+		&cli.StringFlag{Name: flags.TxSource.Name},
+		&cli.StringFlag{Name: flags.TxResubmissionTimeout.Name},
+		&cli.Uint64Flag{Name: flags.TxFeeBumpPercentage.Name},
+		&cli.StringFlag{Name: flags.FeeMaxGasFeeCap.Name},
+		&cli.Float64Flag{Name: flags.FeeTipPercentile.Name},
+		&cli.Uint64Flag{Name: flags.TriggerGasThreshold.Name},
+		&cli.Uint64Flag{Name: flags.TriggerMinGas.Name},
+		&cli.BoolFlag{Name: flags.PrecheckTxLists.Name},
+		&cli.Uint64Flag{Name: flags.BlockTargetGas.Name},
+		&cli.Uint64Flag{Name: flags.BlockMinFillPercentage.Name},
+		&cli.BoolFlag{Name: flags.ProduceInvalidBlocks.Name},
+		&cli.Uint64Flag{Name: flags.ProduceInvalidBlocksInterval.Name},
 	}
 	app.Action = func(ctx *cli.Context) error {
 		c, err := NewConfigFromCliContext(ctx)
@@ -37,10 +52,26 @@ func (s *ProposerTestSuite) TestNewConfigFromCliContext() {
 		s.Equal(l2Endpoint, c.L2Endpoint)
 		s.Equal(taikoL1, c.TaikoL1Address.String())
 		s.Equal(taikoL2, c.TaikoL2Address.String())
-		s.Equal(bindings.GoldenTouchAddress, crypto.PubkeyToAddress(c.L1ProposerPrivKey.PublicKey))
-		s.Equal(bindings.GoldenTouchAddress, c.L2SuggestedFeeRecipient)
+		s.Equal(bindings.GoldenTouchAddress, c.L1ProposerSigner.Address())
+		s.Equal([]*FeeRecipient{
+			{Address: bindings.GoldenTouchAddress, Weight: 1},
+			{Address: common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")), Weight: 2},
+		}, c.L2SuggestedFeeRecipients)
+		s.Equal(FeeRecipientRotationWeighted, c.FeeRecipientRotation)
 		s.Equal(float64(10), c.ProposeInterval.Seconds())
 		s.Equal(uint64(commitSlot), c.CommitSlot)
+		s.Equal(TxOrderingMaxTip, c.TxOrderingStrategy)
+		s.Equal(TxSourceStreaming, c.TxSource)
+		s.Equal(float64(60), c.TxResubmissionTimeout.Seconds())
+		s.Equal(uint64(20), c.TxFeeBumpPercentage)
+		s.Equal(float64(60), c.FeeStrategy.TipPercentile)
+		s.Equal(uint64(1000000000), c.FeeStrategy.MaxGasFeeCap.Uint64())
+		s.Equal(uint64(15000000), c.TriggerGasThreshold)
+		s.Equal(uint64(21000), c.TriggerMinGas)
+		s.True(c.PrecheckTxLists)
+		s.Equal(uint64(3000000), c.BlockTargetGas)
+		s.Equal(uint64(50), c.BlockMinFillPercentage)
+		s.Equal(uint64(5), c.InvalidBlocksInterval)
 		s.Nil(new(Proposer).InitFromCli(context.Background(), ctx))
 
 		return err
@@ -53,8 +84,53 @@ func (s *ProposerTestSuite) TestNewConfigFromCliContext() {
 		"-" + flags.TaikoL1Address.Name, taikoL1,
 		"-" + flags.TaikoL2Address.Name, taikoL2,
 		"-" + flags.L1ProposerPrivKey.Name, bindings.GoldenTouchPrivKey[2:],
-		"-" + flags.L2SuggestedFeeRecipient.Name, bindings.GoldenTouchAddress.Hex(),
+		"-" + flags.L2SuggestedFeeRecipient.Name,
+		bindings.GoldenTouchAddress.Hex() + "," + os.Getenv("L2_SUGGESTED_FEE_RECIPIENT") + ":2",
+		"-" + flags.FeeRecipientRotation.Name, FeeRecipientRotationWeighted,
 		"-" + flags.ProposeInterval.Name, proposeInterval,
 		"-" + flags.CommitSlot.Name, strconv.Itoa(commitSlot),
+		"-" + flags.TxOrderingStrategy.Name, TxOrderingMaxTip,
+		"-" + flags.TxSource.Name, TxSourceStreaming,
+		"-" + flags.TxResubmissionTimeout.Name, "1m",
+		"-" + flags.TxFeeBumpPercentage.Name, "20",
+		"-" + flags.FeeMaxGasFeeCap.Name, "1000000000",
+		"-" + flags.FeeTipPercentile.Name, "60",
+		"-" + flags.TriggerGasThreshold.Name, "15000000",
+		"-" + flags.TriggerMinGas.Name, "21000",
+		"-" + flags.PrecheckTxLists.Name,
+		"-" + flags.BlockTargetGas.Name, "3000000",
+		"-" + flags.BlockMinFillPercentage.Name, "50",
+		"-" + flags.ProduceInvalidBlocks.Name,
+		"-" + flags.ProduceInvalidBlocksInterval.Name, "5",
 	}))
 }
+
+func (s *ProposerTestSuite) TestValidateBlockLimits() {
+	s.Nil((&Config{}).validateBlockLimits(6000000, 2149, 120000))
+	s.Nil((&Config{BlockTargetGas: 6000000, BlockMaxTxs: 100, BlockMaxBytes: 60000}).validateBlockLimits(
+		6000000, 2149, 120000,
+	))
+	s.NotNil((&Config{BlockTargetGas: 6000001}).validateBlockLimits(6000000, 2149, 120000))
+	s.NotNil((&Config{BlockMaxTxs: 2150}).validateBlockLimits(6000000, 2149, 120000))
+	s.NotNil((&Config{BlockMaxBytes: 120001}).validateBlockLimits(6000000, 2149, 120000))
+}
+
+func (s *ProposerTestSuite) TestParseFeeRecipients() {
+	var (
+		addressA = common.BytesToAddress(testutils.RandomBytes(20))
+		addressB = common.BytesToAddress(testutils.RandomBytes(20))
+	)
+
+	recipients, err := parseFeeRecipients(addressA.Hex())
+	s.Nil(err)
+	s.Equal([]*FeeRecipient{{Address: addressA, Weight: 1}}, recipients)
+
+	recipients, err = parseFeeRecipients(addressA.Hex() + ":3, " + addressB.Hex())
+	s.Nil(err)
+	s.Equal([]*FeeRecipient{{Address: addressA, Weight: 3}, {Address: addressB, Weight: 1}}, recipients)
+
+	for _, invalid := range []string{"", "0x123", addressA.Hex() + ":0", addressA.Hex() + ":a", addressA.Hex() + ","} {
+		_, err = parseFeeRecipients(invalid)
+		s.NotNil(err)
+	}
+}
@@ -0,0 +1,156 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// dryRunResult contains the simulation result of proposing a transactions list.
+type dryRunResult struct {
+	txNum       int
+	txListBytes int
+	commitGas   uint64
+	proposeGas  uint64
+	estimated   bool // Whether the gas is estimated by the L1 node, instead of calculated locally
+	l1Cost      *big.Int
+}
+
+// dryRunOp simulates committing and proposing the given transactions lists without sending
+// any L1 transaction, the gas used is estimated through `eth_estimateGas` against TaikoL1
+// contract, and the results are logged and exported as metrics. The fee recipients rotation is
+// only previewed, so that the dry run won't affect the beneficiaries of the real proposals.
+func (p *Proposer) dryRunOp(ctx context.Context, txLists [][]*types.Transaction, txListsBytes [][]byte) error {
+	l1GasPrice, err := p.estimateL1GasPrice(ctx)
+	if err != nil {
+		return err
+	}
+
+	selector := p.feeRecipientSelector.clone()
+	if len(txLists) != 0 {
+		selector.startEpoch()
+	}
+
+	var (
+		totalTxs    int
+		totalBytes  int
+		totalGas    uint64
+		totalL1Cost = new(big.Int)
+	)
+	for i, txs := range txLists {
+		result, err := p.simulateTxList(ctx, txs, txListsBytes[i], l1GasPrice, selector.nextBeneficiary())
+		if err != nil {
+			return err
+		}
+
+		log.Info(
+			"Dry run proposing transactions list",
+			"index", i,
+			"txs", result.txNum,
+			"bytes", result.txListBytes,
+			"commitGas", result.commitGas,
+			"proposeGas", result.proposeGas,
+			"estimated", result.estimated,
+			"l1GasPrice", l1GasPrice,
+			"l1Cost", result.l1Cost,
+		)
+
+		totalTxs += result.txNum
+		totalBytes += result.txListBytes
+		totalGas += result.commitGas + result.proposeGas
+		totalL1Cost.Add(totalL1Cost, result.l1Cost)
+	}
+
+	log.Info(
+		"Dry run proposing epoch finished",
+		"txLists", len(txLists),
+		"txs", totalTxs,
+		"bytes", totalBytes,
+		"gas", totalGas,
+		"l1Cost", totalL1Cost,
+	)
+
+	metrics.ProposerDryRunTxListsGauge.Update(int64(len(txLists)))
+	metrics.ProposerDryRunTxsGauge.Update(int64(totalTxs))
+	metrics.ProposerDryRunBytesGauge.Update(int64(totalBytes))
+	metrics.ProposerDryRunGasGauge.Update(int64(totalGas))
+	metrics.ProposerDryRunL1CostGauge.Update(new(big.Int).Div(totalL1Cost, big.NewInt(params.GWei)).Int64())
+
+	return nil
+}
+
+// simulateTxList estimates the L1 gas used by committing and proposing the given transactions list
+// with the given beneficiary.
+// Since nothing is actually committed, when the commit delay confirmations is enabled, TaikoL1.proposeBlock
+// will always be reverted, in that case the revert reason is logged and the gas will be calculated locally.
+func (p *Proposer) simulateTxList(
+	ctx context.Context,
+	txs []*types.Transaction,
+	txListBytes []byte,
+	l1GasPrice *big.Int,
+	beneficiary common.Address,
+) (*dryRunResult, error) {
+	var (
+		from   = p.l1ProposerSigner.Address()
+		meta   = p.newBlockMetadata(txListBytes, sumTxsGasLimit(txs), beneficiary)
+		result = &dryRunResult{txNum: len(txs), txListBytes: len(txListBytes), estimated: true}
+	)
+
+	if p.commitDelayConfirmations > 0 {
+		commitHash := common.BytesToHash(encoding.EncodeCommitHash(meta.Beneficiary, meta.TxListHash))
+		calldata, err := encoding.TaikoL1ABI.Pack("commitBlock", meta.CommitSlot, commitHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.commitGas, err = p.estimateGas(ctx, from, calldata); err != nil {
+			log.Warn("Failed to estimate TaikoL1.commitBlock gas", "error", err)
+			result.commitGas = params.TxGas + p.l1ExecutionGas.commitBlock + calldataGas(calldata)
+			result.estimated = false
+		}
+	}
+
+	inputs, err := encoding.EncodeProposeBlockInput(meta, txListBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, err := encoding.TaikoL1ABI.Pack("proposeBlock", inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.proposeGas, err = p.estimateGas(ctx, from, calldata); err != nil {
+		log.Debug("Failed to estimate TaikoL1.proposeBlock gas", "error", err)
+		result.proposeGas = params.TxGas + p.l1ExecutionGas.proposeBlock + calldataGas(calldata)
+		result.estimated = false
+	}
+
+	result.l1Cost = new(big.Int).Mul(l1GasPrice, new(big.Int).SetUint64(result.commitGas+result.proposeGas))
+
+	return result, nil
+}
+
+// estimateGas estimates the gas used by a TaikoL1 call with the given calldata, if the estimation
+// fails, the call will be executed through `eth_call` to fetch the revert reason.
+func (p *Proposer) estimateGas(ctx context.Context, from common.Address, calldata []byte) (uint64, error) {
+	msg := ethereum.CallMsg{From: from, To: &p.taikoL1Address, Data: calldata}
+
+	gas, err := p.rpc.L1.EstimateGas(ctx, msg)
+	if err == nil {
+		return gas, nil
+	}
+
+	if _, callErr := p.rpc.L1.CallContract(ctx, msg, nil); callErr != nil {
+		log.Info("TaikoL1 call reverted in dry run", "reason", callErr)
+	}
+
+	return 0, err
+}
@@ -0,0 +1,34 @@
+package proposer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+func (s *ProposerTestSuite) TestSimulateTxList() {
+	txs := []*types.Transaction{
+		types.NewTransaction(0, common.BytesToAddress(testutils.RandomBytes(20)), common.Big1, 21000, common.Big1, nil),
+	}
+	txListBytes, err := rlp.EncodeToBytes(txs)
+	s.Nil(err)
+
+	l1Head, err := s.p.rpc.L1.BlockNumber(context.Background())
+	s.Nil(err)
+
+	result, err := s.p.simulateTxList(context.Background(), txs, txListBytes, common.Big1, common.Address{})
+	s.Nil(err)
+	s.Equal(1, result.txNum)
+	s.Equal(len(txListBytes), result.txListBytes)
+	s.NotZero(result.proposeGas)
+	s.Equal(result.commitGas+result.proposeGas, result.l1Cost.Uint64())
+
+	// Nothing is sent to L1.
+	l1HeadAfter, err := s.p.rpc.L1.BlockNumber(context.Background())
+	s.Nil(err)
+	s.Equal(l1Head, l1HeadAfter)
+	s.Zero(s.p.txMgr.PendingCount())
+}
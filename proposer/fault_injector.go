@@ -0,0 +1,242 @@
+package proposer
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+// FaultTxInvalidNonce is a fault injection scenario besides the InvalidTxListReason ones, which
+// proposes a valid transactions list including a transaction with an invalid nonce, so the
+// transactions list passes the validation, but the transaction can't be executed.
+const FaultTxInvalidNonce = "TX_INVALID_NONCE"
+
+// faultInjectionFile is the JSON format of the fault injection config file.
+type faultInjectionFile struct {
+	Scenarios []*faultInjectionScenario `json:"scenarios"`
+}
+
+// faultInjectionScenario proposes a faulty transactions list with the given reason every `interval`
+// proposing epochs, starting from the `start` epoch, at most `count` times (0 means no limit).
+type faultInjectionScenario struct {
+	Reason   string `json:"reason"`
+	Interval uint64 `json:"interval"`
+	Start    uint64 `json:"start"`
+	Count    uint64 `json:"count"`
+
+	injected uint64
+}
+
+// faultInjector decides when to propose the faulty transactions lists, and assembles them, so
+// that the drivers' throwaway blocks and the provers' invalid block proofs can be exercised one
+// reason at a time. Only for testing purposes.
+type faultInjector struct {
+	scenarios []*faultInjectionScenario
+
+	// Protocol constants
+	maxTxPerBlock      uint64
+	maxGasPerBlock     uint64
+	maxTxBytesPerBlock uint64
+	minTxGasLimit      uint64
+	chainID            *big.Int
+	txListCodec        byte // Only compress the too large lists if the compression is enabled
+
+	// A throwaway account which never has any executed transaction
+	privKey *ecdsa.PrivateKey
+
+	mu    sync.Mutex
+	epoch uint64
+}
+
+// newFaultInjector creates a new faultInjector instance, and loads the scenarios from the
+// given config file, no scenario will be scheduled if the path is empty. If `invalidBlocksInterval`
+// is not 0, the scenarios of the deprecated produceInvalidBlocks flags are scheduled too, which
+// propose an undecodable transactions list and a transactions list including a transaction with an
+// invalid nonce every `invalidBlocksInterval` proposing epochs.
+func newFaultInjector(
+	path string,
+	invalidBlocksInterval uint64,
+	splitter *poolContentSplitter,
+	chainID *big.Int,
+) (*faultInjector, error) {
+	var file faultInjectionFile
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fault injection config file: %w", err)
+		}
+
+		if err := json.Unmarshal(b, &file); err != nil {
+			return nil, fmt.Errorf("failed to decode fault injection config file: %w", err)
+		}
+	}
+
+	if invalidBlocksInterval != 0 {
+		for _, reason := range []string{txListValidator.HintBinaryNotDecodable.String(), FaultTxInvalidNonce} {
+			file.Scenarios = append(file.Scenarios, &faultInjectionScenario{
+				Reason:   reason,
+				Interval: invalidBlocksInterval,
+				Start:    invalidBlocksInterval,
+			})
+		}
+	}
+
+	for _, scenario := range file.Scenarios {
+		if scenario.Reason != FaultTxInvalidNonce {
+			reason, err := txListValidator.ParseInvalidTxListReason(scenario.Reason)
+			if err != nil {
+				return nil, err
+			}
+
+			if reason == txListValidator.HintOK {
+				return nil, errors.New("fault injection scenario reason can't be OK")
+			}
+		}
+
+		if scenario.Interval == 0 {
+			return nil, fmt.Errorf("fault injection scenario interval must be positive: %s", scenario.Reason)
+		}
+	}
+
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &faultInjector{
+		scenarios:          file.Scenarios,
+		maxTxPerBlock:      splitter.maxTxPerBlock,
+		maxGasPerBlock:     splitter.maxGasPerBlock,
+		maxTxBytesPerBlock: splitter.maxTxBytesPerBlock,
+		minTxGasLimit:      splitter.minTxGasLimit,
+		chainID:            chainID,
+		txListCodec:        splitter.txListCodec,
+		privKey:            privKey,
+	}, nil
+}
+
+// nextEpoch moves to the next proposing epoch, and returns the new epoch and the reasons of
+// the scenarios scheduled in it.
+func (f *faultInjector) nextEpoch() (uint64, []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.epoch++
+
+	var reasons []string
+	for _, scenario := range f.scenarios {
+		if f.epoch < scenario.Start || (f.epoch-scenario.Start)%scenario.Interval != 0 {
+			continue
+		}
+
+		if scenario.Count != 0 && scenario.injected >= scenario.Count {
+			continue
+		}
+
+		scenario.injected++
+		reasons = append(reasons, scenario.Reason)
+	}
+
+	return f.epoch, reasons
+}
+
+// faultyTxList assembles a transactions list with the given fault reason, and returns its
+// payload and the gas limit of the block to propose. Note that TaikoL1 might reject a
+// BINARY_TOO_LARGE proposal, if it checks the payload size too.
+func (f *faultInjector) faultyTxList(reason string) ([]byte, uint64, error) {
+	var txs types.Transactions
+
+	switch reason {
+	case txListValidator.HintBinaryTooLarge.String():
+		return testutils.RandomBytes(int(f.maxTxBytesPerBlock) + 1), f.maxGasPerBlock, nil
+	case txListValidator.HintBinaryNotDecodable.String():
+		return testutils.RandomBytes(256), f.maxGasPerBlock, nil
+	case txListValidator.HintBlockTooManyTxs.String():
+		tx, err := f.signTx(0, f.minTxGasLimit, f.chainID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// The same transaction repeated, so the payload can be compressed to fit in the limit.
+		for i := uint64(0); i <= f.maxTxPerBlock; i++ {
+			txs = append(txs, tx)
+		}
+	case txListValidator.HintBlockGasLimitTooLarge.String():
+		for nonce := uint64(0); nonce < 2; nonce++ {
+			tx, err := f.signTx(nonce, f.maxGasPerBlock/2+1, f.chainID)
+			if err != nil {
+				return nil, 0, err
+			}
+			txs = append(txs, tx)
+		}
+	case txListValidator.HintTxInvalidSig.String():
+		tx, err := f.signTx(0, f.minTxGasLimit, new(big.Int).Add(f.chainID, common.Big1))
+		if err != nil {
+			return nil, 0, err
+		}
+		txs = append(txs, tx)
+	case txListValidator.HintTxGasLimitTooSmall.String():
+		if f.minTxGasLimit == 0 {
+			return nil, 0, errors.New("no transaction gas limit is too small")
+		}
+
+		tx, err := f.signTx(0, f.minTxGasLimit-1, f.chainID)
+		if err != nil {
+			return nil, 0, err
+		}
+		txs = append(txs, tx)
+	case FaultTxInvalidNonce:
+		tx, err := f.signTx(1024, f.minTxGasLimit, f.chainID)
+		if err != nil {
+			return nil, 0, err
+		}
+		txs = append(txs, tx)
+	default:
+		return nil, 0, fmt.Errorf("unknown fault injection reason: %s", reason)
+	}
+
+	txListBytes, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(txListBytes) > int(f.maxTxBytesPerBlock) && f.txListCodec != encoding.TxListCodecNone {
+		if txListBytes, err = encoding.CompressTxListBytes(txListBytes, f.txListCodec); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	gasLimit := sumTxsGasLimit(txs)
+	if gasLimit > f.maxGasPerBlock {
+		gasLimit = f.maxGasPerBlock
+	}
+
+	return txListBytes, gasLimit, nil
+}
+
+// signTx signs a transaction with the given nonce and gas limit by the throwaway account.
+func (f *faultInjector) signTx(nonce uint64, gasLimit uint64, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			Gas:       gasLimit,
+			GasFeeCap: common.Big1,
+			To:        &common.Address{},
+		}),
+		types.LatestSignerForChainID(chainID),
+		f.privKey,
+	)
+}
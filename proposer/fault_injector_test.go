@@ -0,0 +1,126 @@
+package proposer
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+)
+
+func (s *ProposerTestSuite) TestFaultInjectorSchedule() {
+	var (
+		path     = filepath.Join(s.T().TempDir(), "faultInjection.json")
+		splitter = &poolContentSplitter{}
+	)
+
+	s.Nil(os.WriteFile(path, []byte(`{"scenarios":[
+		{"reason":"TX_INVALID_SIG","interval":2},
+		{"reason":"TX_INVALID_NONCE","interval":3,"start":4,"count":2}
+	]}`), 0600))
+
+	injector, err := newFaultInjector(path, 0, splitter, big.NewInt(167))
+	s.Nil(err)
+
+	var scheduled [][]string
+	for i := 0; i < 12; i++ {
+		_, reasons := injector.nextEpoch()
+		scheduled = append(scheduled, reasons)
+	}
+
+	s.Equal([][]string{
+		nil,
+		{"TX_INVALID_SIG"},
+		nil,
+		{"TX_INVALID_SIG", "TX_INVALID_NONCE"},
+		nil,
+		{"TX_INVALID_SIG"},
+		{"TX_INVALID_NONCE"},
+		{"TX_INVALID_SIG"},
+		nil,
+		{"TX_INVALID_SIG"},
+		nil,
+		{"TX_INVALID_SIG"},
+	}, scheduled)
+
+	// Deprecated produceInvalidBlocks flags
+	injector, err = newFaultInjector("", 3, splitter, big.NewInt(167))
+	s.Nil(err)
+
+	scheduled = nil
+	for i := 0; i < 6; i++ {
+		_, reasons := injector.nextEpoch()
+		scheduled = append(scheduled, reasons)
+	}
+	s.Equal([][]string{
+		nil,
+		nil,
+		{"BINARY_NOT_DECODABLE", "TX_INVALID_NONCE"},
+		nil,
+		nil,
+		{"BINARY_NOT_DECODABLE", "TX_INVALID_NONCE"},
+	}, scheduled)
+
+	// Invalid scenarios
+	for _, content := range []string{
+		`{"scenarios":[{"reason":"OK","interval":1}]}`,
+		`{"scenarios":[{"reason":"UNKNOWN","interval":1}]}`,
+		`{"scenarios":[{"reason":"TX_INVALID_SIG"}]}`,
+		`{"scenarios":`,
+	} {
+		s.Nil(os.WriteFile(path, []byte(content), 0600))
+
+		_, err = newFaultInjector(path, 0, splitter, big.NewInt(167))
+		s.NotNil(err)
+	}
+}
+
+func (s *ProposerTestSuite) TestFaultyTxList() {
+	splitter := &poolContentSplitter{
+		maxTxPerBlock:      2149,
+		maxGasPerBlock:     6000000,
+		maxTxBytesPerBlock: 120000,
+		minTxGasLimit:      21000,
+		txListCodec:        encoding.TxListCodecZlib,
+	}
+
+	injector, err := newFaultInjector("", 0, splitter, big.NewInt(167))
+	s.Nil(err)
+
+	validator := txListValidator.NewTxListValidator(
+		splitter.maxGasPerBlock,
+		splitter.maxTxPerBlock,
+		splitter.maxTxBytesPerBlock,
+		splitter.minTxGasLimit,
+		injector.chainID,
+		true,
+	)
+
+	for reason := txListValidator.HintBinaryTooLarge; reason <= txListValidator.HintTxGasLimitTooSmall; reason++ {
+		txListBytes, gasLimit, err := injector.faultyTxList(reason.String())
+		s.Nil(err)
+		s.LessOrEqual(gasLimit, splitter.maxGasPerBlock)
+
+		hint, _ := validator.IsTxListValid(nil, txListBytes)
+		s.Equal(reason, hint)
+
+		if reason != txListValidator.HintBinaryTooLarge {
+			s.LessOrEqual(len(txListBytes), int(splitter.maxTxBytesPerBlock))
+		}
+	}
+
+	txListBytes, _, err := injector.faultyTxList(FaultTxInvalidNonce)
+	s.Nil(err)
+
+	hint, _ := validator.IsTxListValid(nil, txListBytes)
+	s.Equal(txListValidator.HintOK, hint)
+
+	_, _, err = injector.faultyTxList("UNKNOWN")
+	s.NotNil(err)
+
+	// Too many transactions are compressed to fit in the limit.
+	txListBytes, _, err = injector.faultyTxList(txListValidator.HintBlockTooManyTxs.String())
+	s.Nil(err)
+	s.Equal(encoding.TxListCodecZlib, txListBytes[0])
+}
@@ -0,0 +1,120 @@
+package proposer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// All built-in L2 suggested fee recipient rotation rules.
+const (
+	FeeRecipientRotationRoundRobin = "roundRobin"
+	FeeRecipientRotationEpoch      = "epoch"
+	FeeRecipientRotationWeighted   = "weighted"
+)
+
+// FeeRecipient is an L2 suggested fee recipient, and its weight in the weighted rotation.
+type FeeRecipient struct {
+	Address common.Address
+	Weight  uint64
+}
+
+// feeRecipientSelector decides the beneficiary of each proposed block when multiple L2 suggested
+// fee recipients are configured.
+type feeRecipientSelector struct {
+	rotation   string
+	recipients []*FeeRecipient
+
+	mu             sync.Mutex
+	next           int     // Index of the next recipient in the round-robin and epoch rotations
+	epochRecipient int     // Index of the current epoch's recipient in the epoch rotation
+	currentWeights []int64 // Current weights of the smooth weighted rotation
+}
+
+// newFeeRecipientSelector creates a new feeRecipientSelector instance with the given rotation rule.
+func newFeeRecipientSelector(rotation string, recipients []*FeeRecipient) (*feeRecipientSelector, error) {
+	switch rotation {
+	case "", FeeRecipientRotationRoundRobin, FeeRecipientRotationEpoch, FeeRecipientRotationWeighted:
+	default:
+		return nil, fmt.Errorf("unknown fee recipient rotation: %s", rotation)
+	}
+
+	for _, recipient := range recipients {
+		if recipient.Weight == 0 {
+			return nil, fmt.Errorf("fee recipient weight must be positive: %s", recipient.Address)
+		}
+	}
+
+	return &feeRecipientSelector{
+		rotation:       rotation,
+		recipients:     recipients,
+		currentWeights: make([]int64, len(recipients)),
+	}, nil
+}
+
+// clone returns a copy of the selector, so that the rotation can be previewed, e.g. in the dry run
+// mode, without moving the selector itself.
+func (s *feeRecipientSelector) clone() *feeRecipientSelector {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &feeRecipientSelector{
+		rotation:       s.rotation,
+		recipients:     s.recipients,
+		next:           s.next,
+		epochRecipient: s.epochRecipient,
+		currentWeights: append([]int64{}, s.currentWeights...),
+	}
+}
+
+// startEpoch moves the epoch rotation to the next recipient, it should be called once
+// at the beginning of each proposing epoch which commits transactions lists.
+func (s *feeRecipientSelector) startEpoch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rotation != FeeRecipientRotationEpoch || len(s.recipients) == 0 {
+		return
+	}
+
+	s.epochRecipient = s.next
+	s.next = (s.next + 1) % len(s.recipients)
+}
+
+// nextBeneficiary returns the beneficiary of the next proposed block.
+func (s *feeRecipientSelector) nextBeneficiary() common.Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.recipients) == 0 {
+		return common.Address{}
+	}
+
+	switch s.rotation {
+	case FeeRecipientRotationEpoch:
+		return s.recipients[s.epochRecipient].Address
+	case FeeRecipientRotationWeighted:
+		// Smooth weighted round-robin, so the blocks are split between the recipients exactly
+		// by their weights, and evenly interleaved.
+		var (
+			best        int
+			totalWeight int64
+		)
+		for i, recipient := range s.recipients {
+			s.currentWeights[i] += int64(recipient.Weight)
+			totalWeight += int64(recipient.Weight)
+			if s.currentWeights[i] > s.currentWeights[best] {
+				best = i
+			}
+		}
+		s.currentWeights[best] -= totalWeight
+
+		return s.recipients[best].Address
+	default:
+		recipient := s.recipients[s.next]
+		s.next = (s.next + 1) % len(s.recipients)
+
+		return recipient.Address
+	}
+}
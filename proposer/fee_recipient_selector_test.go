@@ -0,0 +1,69 @@
+package proposer
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+func (s *ProposerTestSuite) TestFeeRecipientSelector() {
+	var (
+		addressA   = common.BytesToAddress(testutils.RandomBytes(20))
+		addressB   = common.BytesToAddress(testutils.RandomBytes(20))
+		addressC   = common.BytesToAddress(testutils.RandomBytes(20))
+		recipients = []*FeeRecipient{{addressA, 1}, {addressB, 2}, {addressC, 1}}
+	)
+
+	// Round-robin
+	selector, err := newFeeRecipientSelector(FeeRecipientRotationRoundRobin, recipients)
+	s.Nil(err)
+
+	selector.startEpoch()
+	for _, expected := range []common.Address{addressA, addressB, addressC, addressA} {
+		s.Equal(expected, selector.nextBeneficiary())
+	}
+
+	// Epoch
+	selector, err = newFeeRecipientSelector(FeeRecipientRotationEpoch, recipients)
+	s.Nil(err)
+
+	for _, expected := range []common.Address{addressA, addressB, addressC, addressA} {
+		selector.startEpoch()
+		s.Equal(expected, selector.nextBeneficiary())
+		s.Equal(expected, selector.nextBeneficiary())
+	}
+
+	// Weighted
+	selector, err = newFeeRecipientSelector(FeeRecipientRotationWeighted, recipients)
+	s.Nil(err)
+
+	counts := make(map[common.Address]int)
+	for i := 0; i < 400; i++ {
+		counts[selector.nextBeneficiary()]++
+	}
+	s.Equal(map[common.Address]int{addressA: 100, addressB: 200, addressC: 100}, counts)
+
+	// Previewing the rotation doesn't move the selector.
+	selector, err = newFeeRecipientSelector(FeeRecipientRotationRoundRobin, recipients)
+	s.Nil(err)
+
+	preview := selector.clone()
+	s.Equal(addressA, preview.nextBeneficiary())
+	s.Equal(addressB, preview.nextBeneficiary())
+	s.Equal(addressA, selector.nextBeneficiary())
+
+	selector, err = newFeeRecipientSelector(FeeRecipientRotationWeighted, recipients)
+	s.Nil(err)
+
+	preview = selector.clone()
+	for i := 0; i < 3; i++ {
+		preview.nextBeneficiary()
+	}
+	s.Equal(addressB, selector.nextBeneficiary())
+	s.Equal(addressA, selector.nextBeneficiary())
+
+	// Invalid configurations
+	_, err = newFeeRecipientSelector("unknown", recipients)
+	s.NotNil(err)
+	_, err = newFeeRecipientSelector(FeeRecipientRotationWeighted, []*FeeRecipient{{addressA, 0}})
+	s.NotNil(err)
+}
@@ -3,41 +3,35 @@ package proposer
 import (
 	"context"
 	"fmt"
-	"math/big"
-	"math/rand"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/taikoxyz/taiko-client/testutils"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
 )
 
-var (
-	globalEpoch uint64 = 0
-)
-
-// ProposeInvalidBlocksOp tries to propose invalid blocks to TaikoL1 contract
-// every `interval` normal propose operations.
-func (p *Proposer) ProposeInvalidBlocksOp(ctx context.Context, interval uint64) error {
-	globalEpoch += 1
-
-	if globalEpoch%interval != 0 {
+// InjectFaultsOp commits the faulty transactions lists scheduled in the current proposing epoch to
+// TaikoL1 contract, and enqueues them to be proposed by the proposeLoop like the normal ones, only
+// for testing purposes. Nothing is injected in dry-run mode.
+func (p *Proposer) InjectFaultsOp(ctx context.Context) error {
+	if p.dryRun {
 		return nil
 	}
 
-	log.Info("👻 Propose invalid transactions list bytes", "epoch", globalEpoch)
+	epoch, reasons := p.faultInjector.nextEpoch()
+	for _, reason := range reasons {
+		if inFlightCommits := len(p.getInFlightCommits()); inFlightCommits >= int(p.maxPendingBlocks) {
+			log.Info("Too many in-flight commits, skip injecting faults", "inFlight", inFlightCommits, "epoch", epoch)
+			return nil
+		}
 
-	if err := p.ProposeInvalidTxListBytes(ctx); err != nil {
-		return fmt.Errorf("failed to propose invalid transaction list bytes: %w", err)
-	}
+		log.Info("👻 Commit faulty transactions list", "reason", reason, "epoch", epoch)
 
-	log.Info("👻 Propose transactions list including invalid transaction", "epoch", globalEpoch)
+		commitRes, err := p.commitFaultyTxList(ctx, reason)
+		if err != nil {
+			return fmt.Errorf("failed to commit faulty transactions list (%s): %w", reason, err)
+		}
 
-	if err := p.proposeTxListIncludingInvalidTx(ctx); err != nil {
-		return fmt.Errorf("failed to propose transactions list including invalid transaction: %w", err)
+		p.addInFlightCommit(commitRes)
+		p.commitTxListResQueue <- commitRes
 	}
 
 	return nil
@@ -46,42 +40,35 @@ func (p *Proposer) ProposeInvalidBlocksOp(ctx context.Context, interval uint64)
 // ProposeInvalidTxListBytes commits and proposes an invalid transaction list
 // bytes to TaikoL1 contract.
 func (p *Proposer) ProposeInvalidTxListBytes(ctx context.Context) error {
-	invalidTxListBytes := testutils.RandomBytes(256)
-	meta, commitTx, err := p.CommitTxList(
-		ctx,
-		invalidTxListBytes,
-		uint64(rand.Int63n(int64(p.poolContentSplitter.maxGasPerBlock))),
-		0,
-	)
+	return p.proposeFaultyTxList(ctx, txListValidator.HintBinaryNotDecodable.String())
+}
+
+// proposeFaultyTxList commits and proposes a transactions list with the given fault reason.
+func (p *Proposer) proposeFaultyTxList(ctx context.Context, reason string) error {
+	commitRes, err := p.commitFaultyTxList(ctx, reason)
 	if err != nil {
 		return err
 	}
 
-	if p.AfterCommitHook != nil {
-		if err := p.AfterCommitHook(); err != nil {
-			log.Error("Run AfterCommitHook error", "error", err)
-		}
-	}
-
-	return p.ProposeTxList(ctx, &commitTxListRes{meta, commitTx, invalidTxListBytes, 1})
+	return p.ProposeTxList(ctx, commitRes)
 }
 
-// proposeTxListIncludingInvalidTx commits and proposes a validly encoded
-// transaction list which including an invalid transaction.
-func (p *Proposer) proposeTxListIncludingInvalidTx(ctx context.Context) error {
-	invalidTx, err := p.generateInvalidTransaction(ctx)
+// commitFaultyTxList commits a transactions list with the given fault reason to TaikoL1 contract.
+func (p *Proposer) commitFaultyTxList(ctx context.Context, reason string) (*commitTxListRes, error) {
+	txListBytes, gasLimit, err := p.faultInjector.faultyTxList(reason)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	txListBytes, err := rlp.EncodeToBytes(types.Transactions{invalidTx})
-	if err != nil {
-		return err
+	if p.commitDelayConfirmations > 0 {
+		if err := p.commitSlotAllocator.sync(ctx, p.rpc); err != nil {
+			return nil, fmt.Errorf("failed to sync commit slots: %w", err)
+		}
 	}
 
-	meta, commitTx, err := p.CommitTxList(ctx, txListBytes, invalidTx.Gas(), 0)
+	meta, commitTx, err := p.CommitTxList(ctx, txListBytes, gasLimit)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if p.AfterCommitHook != nil {
@@ -90,25 +77,5 @@ func (p *Proposer) proposeTxListIncludingInvalidTx(ctx context.Context) error {
 		}
 	}
 
-	return p.ProposeTxList(ctx, &commitTxListRes{meta, commitTx, txListBytes, 1})
-}
-
-// generateInvalidTransaction creates a transaction with an invalid nonce to
-// current L2 world state.
-func (p *Proposer) generateInvalidTransaction(ctx context.Context) (*types.Transaction, error) {
-	opts, err := bind.NewKeyedTransactorWithChainID(p.l1ProposerPrivKey, p.rpc.L2ChainID)
-	if err != nil {
-		return nil, err
-	}
-
-	nonce, err := p.rpc.L2.PendingNonceAt(ctx, crypto.PubkeyToAddress(p.l1ProposerPrivKey.PublicKey))
-	if err != nil {
-		return nil, err
-	}
-
-	opts.GasLimit = 300000
-	opts.NoSend = true
-	opts.Nonce = new(big.Int).SetUint64(nonce + 1024)
-
-	return p.rpc.TaikoL2.Anchor(opts, common.Big0, common.BytesToHash(testutils.RandomBytes(32)))
+	return &commitTxListRes{meta: meta, commitTx: commitTx, txListBytes: txListBytes, txNum: 1}, nil
 }
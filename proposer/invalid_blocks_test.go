@@ -7,11 +7,23 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
 )
 
-func (s *ProposerTestSuite) TestProposeInvalidBlocksOp() {
-	s.Nil(s.p.ProposeInvalidBlocksOp(context.Background(), 1024))
-	s.Nil(s.p.ProposeInvalidBlocksOp(context.Background(), 2))
+func (s *ProposerTestSuite) TestInjectFaultsOp() {
+	s.p.faultInjector.scenarios = []*faultInjectionScenario{
+		{Reason: txListValidator.HintTxInvalidSig.String(), Interval: 2, Count: 1},
+	}
+	defer func() { s.p.faultInjector.scenarios = nil }()
+
+	s.Nil(s.p.InjectFaultsOp(context.Background()))
+	s.Nil(s.p.InjectFaultsOp(context.Background()))
+
+	// The faulty transactions list is enqueued like the normal ones.
+	s.Equal(1, len(s.p.commitTxListResQueue))
+	commitRes := <-s.p.commitTxListResQueue
+	s.Equal([]*commitTxListRes{commitRes}, s.p.getInFlightCommits())
+	s.p.removeInFlightCommit(commitRes)
 }
 
 func (s *ProposerTestSuite) TestProposeInvalidTxListBytes() {
@@ -46,7 +58,7 @@ func (s *ProposerTestSuite) TestProposeTxListIncludingInvalidTx() {
 		close(sink)
 	}()
 
-	s.Nil(s.p.proposeTxListIncludingInvalidTx(context.Background()))
+	s.Nil(s.p.proposeFaultyTxList(context.Background(), FaultTxInvalidNonce))
 
 	event := <-sink
 
@@ -0,0 +1,192 @@
+package proposer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// Maximum number of the new pending transactions messages handled at once, the hash-only
+// messages' transactions are fetched in one batch request.
+const maxPendingTxsBatchSize = 256
+
+// pendingTxsWatcher subscribes the L2 node's new pending transactions, and feeds them to both
+// the streaming transactions source and the proposeTrigger, so that only one subscription is
+// created for the proposer.
+type pendingTxsWatcher struct {
+	rpc             *rpc.Client
+	txsFeed         event.Feed // []*types.Transaction
+	resubscribeFeed event.Feed // struct{}, some transactions might be missed before resubscribing
+	startOnce       sync.Once
+	wg              sync.WaitGroup
+}
+
+// newPendingTxsWatcher creates a new pendingTxsWatcher instance.
+func newPendingTxsWatcher(cli *rpc.Client) *pendingTxsWatcher {
+	return &pendingTxsWatcher{rpc: cli}
+}
+
+// subscribe subscribes the new pending transactions.
+func (w *pendingTxsWatcher) subscribe(ch chan<- []*types.Transaction) event.Subscription {
+	return w.txsFeed.Subscribe(ch)
+}
+
+// subscribeResubscriptions subscribes the notifications sent after the L2 node's subscription
+// has been recreated.
+func (w *pendingTxsWatcher) subscribeResubscriptions(ch chan<- struct{}) event.Subscription {
+	return w.resubscribeFeed.Subscribe(ch)
+}
+
+// start starts watching the new pending transactions in background until the given context
+// is canceled, it only takes effect once.
+func (w *pendingTxsWatcher) start(ctx context.Context) {
+	w.startOnce.Do(func() {
+		var (
+			msgCh      = make(chan json.RawMessage, 1024)
+			subscribed bool
+		)
+
+		sub := event.ResubscribeErr(
+			backoff.DefaultMaxInterval,
+			func(ctx context.Context, err error) (event.Subscription, error) {
+				if err != nil {
+					log.Warn("Failed to subscribe L2 new pending transactions, try resubscribing", "error", err)
+				}
+
+				if subscribed {
+					w.resubscribeFeed.Send(struct{}{})
+				}
+				subscribed = true
+
+				return w.watchPendingTxs(ctx, msgCh)
+			},
+		)
+
+		w.wg.Add(1)
+		go func() {
+			defer func() {
+				sub.Unsubscribe()
+				w.wg.Done()
+			}()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-msgCh:
+					if txs := w.decodeMessages(ctx, drainMessages(msgCh, msg)); len(txs) != 0 {
+						w.txsFeed.Send(txs)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// close waits for the background goroutine to exit.
+func (w *pendingTxsWatcher) close() {
+	w.wg.Wait()
+}
+
+// drainMessages returns the given message together with the messages already queued in the channel.
+func drainMessages(ch <-chan json.RawMessage, msg json.RawMessage) []json.RawMessage {
+	msgs := []json.RawMessage{msg}
+	for len(msgs) < maxPendingTxsBatchSize {
+		select {
+		case msg := <-ch:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+
+	return msgs
+}
+
+// decodeMessages decodes the new pending transactions subscription messages, each of which is either
+// a full transaction or only its hash, based on the L2 node's support. The transactions of the hash-only
+// messages are fetched in one batch request.
+func (w *pendingTxsWatcher) decodeMessages(ctx context.Context, msgs []json.RawMessage) []*types.Transaction {
+	var (
+		txs    = make([]*types.Transaction, 0, len(msgs))
+		hashes []common.Hash
+	)
+	for _, msg := range msgs {
+		var hash common.Hash
+		if err := json.Unmarshal(msg, &hash); err == nil {
+			hashes = append(hashes, hash)
+			continue
+		}
+
+		tx := new(types.Transaction)
+		if err := json.Unmarshal(msg, tx); err != nil {
+			log.Debug("Failed to decode new pending transaction", "error", err)
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	if len(hashes) == 0 {
+		return txs
+	}
+
+	var (
+		fetched = make([]*types.Transaction, len(hashes))
+		batch   = make([]gethRPC.BatchElem, len(hashes))
+	)
+	for i, hash := range hashes {
+		batch[i] = gethRPC.BatchElem{Method: "eth_getTransactionByHash", Args: []interface{}{hash}, Result: &fetched[i]}
+	}
+
+	if err := w.rpc.L2RawRPC.BatchCallContext(ctx, batch); err != nil {
+		log.Debug("Failed to fetch new pending transactions", "count", len(hashes), "error", err)
+		return txs
+	}
+
+	for i, elem := range batch {
+		// The transaction might have already left the transaction pool.
+		if elem.Error != nil || fetched[i] == nil {
+			log.Debug("Failed to fetch new pending transaction", "hash", hashes[i], "error", elem.Error)
+			continue
+		}
+
+		txs = append(txs, fetched[i])
+	}
+
+	return txs
+}
+
+// watchPendingTxs watches the L2 node's new pending transactions, full transactions are
+// subscribed if the L2 node supports, otherwise only the hashes.
+func (w *pendingTxsWatcher) watchPendingTxs(
+	ctx context.Context,
+	ch chan<- json.RawMessage,
+) (event.Subscription, error) {
+	sub, err := w.rpc.L2RawRPC.EthSubscribe(ctx, ch, "newPendingTransactions", true)
+	if err != nil {
+		log.Debug("Full pending transactions subscription not supported, subscribe hashes", "error", err)
+
+		if sub, err = w.rpc.L2RawRPC.EthSubscribe(ctx, ch, "newPendingTransactions"); err != nil {
+			log.Error("Create L2 new pending transactions subscription error", "error", err)
+			return nil, err
+		}
+	}
+
+	defer sub.Unsubscribe()
+
+	select {
+	case err := <-sub.Err():
+		return sub, err
+	case <-ctx.Done():
+		return sub, nil
+	}
+}
@@ -2,11 +2,13 @@ package proposer
 
 import (
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/les/utils"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/metrics"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 )
@@ -14,50 +16,115 @@ import (
 // poolContentSplitter is responsible for splitting the pool content
 // which fetched from `txpool_content` RPC into several transactions lists
 // and make sure each splitted list satisfies the limits defined in Taiko
-// protocol.
+// protocol, and the operator limits if configured.
 type poolContentSplitter struct {
 	shufflePoolContent bool
+	txOrderingStrategy TxOrderingStrategy
+	senderLists        *senderLists       // Priority lane and denylist, nil if not configured
+	priorityOrdering   TxOrderingStrategy // Separate instance for the priority lane, since the strategy might be stateful
+	txListCodec        byte               // Compression codec of the proposed transactions lists
 	maxTxPerBlock      uint64
 	maxGasPerBlock     uint64
 	maxTxBytesPerBlock uint64
 	minTxGasLimit      uint64
+
+	// Operator limits of each transactions list, at most the protocol limits above, 0 to use the
+	// protocol limits. A transaction beyond these limits alone is still proposed in its own list.
+	blockTargetGas uint64
+	blockMaxTxs    uint64
+	blockMaxBytes  uint64
+
+	// Defer the last transactions list if its gas limit is below this percentage of the target gas,
+	// to wait for more pending transactions, 0 to disable.
+	minFillPercentage uint64
+	maxDeferredEpochs uint64 // Propose an underfilled list anyway after deferring it these many epochs in a row
+	deferredEpochs    uint64 // Number of epochs deferred in a row, only accessed by the event loop
 }
 
 // split splits the given transaction pool content to make each splitted
-// transactions list satisfies the rules defined in Taiko protocol.
-func (p *poolContentSplitter) split(poolContent rpc.PoolContent) [][]*types.Transaction {
+// transactions list satisfies the rules defined in Taiko protocol, the transactions
+// are filled in the order decided by the splitter's TxOrderingStrategy, `baseFee` is
+// the base fee of the L2 block which the transactions will be included in. If the
+// sender lists are configured, the priority lane is always filled before the general
+// pool, and the denied senders' transactions are ignored.
+func (p *poolContentSplitter) split(poolContent rpc.PoolContent, baseFee *big.Int) [][]*types.Transaction {
 	var (
-		txLists                = poolContent.ToTxLists()
 		splittedTxLists        = make([][]*types.Transaction, 0)
-		txBuffer               = make([]*types.Transaction, 0, p.maxTxPerBlock)
+		txBuffer               = make([]*types.Transaction, 0, p.txLimit())
 		gasBuffer       uint64 = 0
+		sizeBuffer             = new(txBufferSize)
+		strategy               = p.txOrderingStrategy
 	)
 
+	if strategy == nil {
+		strategy = new(senderNonceOrdering)
+	}
+
 	if p.shufflePoolContent {
-		txLists = p.weightedShuffle(txLists)
+		strategy = new(weightedRandomOrdering)
+	}
+
+	priorityStrategy := p.priorityOrdering
+	if priorityStrategy == nil || p.shufflePoolContent {
+		priorityStrategy = strategy
+	}
+
+	var (
+		iter          TxIterator
+		senders       map[common.Hash]common.Address
+		poppedSenders = make(map[common.Address]struct{})
+	)
+	if p.senderLists != nil {
+		priority, general := p.senderLists.partition(poolContent)
+		iter = newChainedTxIterator(priorityStrategy.Order(priority, baseFee), strategy.Order(general, baseFee))
+
+		// A sender's transactions might be in both lanes, so we need to know the senders
+		// to ignore the general lane's remaining transactions of a popped sender.
+		senders = make(map[common.Hash]common.Address)
+		for sender, txs := range poolContent {
+			for _, tx := range txs {
+				senders[tx.Hash()] = sender
+			}
+		}
+	} else {
+		iter = strategy.Order(poolContent.ToTxLists(), baseFee)
 	}
 
-	for _, txList := range txLists {
-		for _, tx := range txList {
-			// If the transaction is invalid, we simply ignore it.
-			if err := p.validateTx(tx); err != nil {
-				log.Debug("Invalid pending transaction", "hash", tx.Hash(), "error", err)
-				metrics.ProposerInvalidTxsCounter.Inc(1)
-				break // If this tx is invalid, ingore this sender's other txs with larger nonce.
+	for tx := iter.Peek(); tx != nil; tx = iter.Peek() {
+		if senders != nil {
+			if _, ok := poppedSenders[senders[tx.Hash()]]; ok {
+				iter.Pop()
+				continue
 			}
+		}
 
-			// If the transactions buffer is full, we make all transactions in
-			// current buffer a new splitted transaction list, and then reset the
-			// buffer.
-			if p.isTxBufferFull(tx, txBuffer, gasBuffer) {
-				splittedTxLists = append(splittedTxLists, txBuffer)
-				txBuffer = make([]*types.Transaction, 0, p.maxTxPerBlock)
-				gasBuffer = 0
+		// If the transaction is invalid, we simply ignore it.
+		if err := p.validateTx(tx); err != nil {
+			log.Debug("Invalid pending transaction", "hash", tx.Hash(), "error", err)
+			metrics.ProposerInvalidTxsCounter.Inc(1)
+			iter.Pop() // If this tx is invalid, ingore this sender's other txs with larger nonce.
+			if senders != nil {
+				poppedSenders[senders[tx.Hash()]] = struct{}{}
 			}
+			continue
+		}
 
-			txBuffer = append(txBuffer, tx)
-			gasBuffer += tx.Gas()
+		// If the transactions buffer is full, we make all transactions in
+		// current buffer a new splitted transaction list, and then reset the
+		// buffer.
+		// Transaction's RLP encoding error has already been checked in `validateTx`.
+		txBytes, _ := rlp.EncodeToBytes(tx)
+		if len(txBuffer) != 0 && p.isTxBufferFull(tx, txBuffer, gasBuffer, sizeBuffer, uint64(len(txBytes))) {
+			splittedTxLists = append(splittedTxLists, txBuffer)
+			txBuffer = make([]*types.Transaction, 0, p.txLimit())
+			gasBuffer = 0
+			sizeBuffer = new(txBufferSize)
 		}
+
+		txBuffer = append(txBuffer, tx)
+		gasBuffer += tx.Gas()
+		sizeBuffer.rlp += uint64(len(txBytes))
+		iter.Shift()
 	}
 
 	// Maybe there are some remaining transactions in current buffer,
@@ -101,50 +168,132 @@ func (p *poolContentSplitter) validateTx(tx *types.Transaction) error {
 	return nil
 }
 
+// txBufferSize tracks the encoded size of the transactions buffer, so that neither the buffer's RLP
+// encoding nor its compression has to be computed again for every appended transaction.
+type txBufferSize struct {
+	rlp           uint64 // Sum of the buffered transactions' RLP encoded sizes
+	compressed    uint64 // Compressed size of the buffer when it was last compressed, 0 if never
+	compressedRLP uint64 // Value of `rlp` when the buffer was last compressed
+}
+
+// Upper bound of the bytes a compressed transactions list grows by, besides the appended transactions'
+// RLP encoded sizes, i.e. the longer RLP list prefix and the extra deflate block headers.
+const compressionOverhead = 32
+
 // isTxBufferFull checks whether the given transaction can be appended to the
-// current transaction list
+// current transaction list, `txSize` is the size of the transaction's RLP encoding.
 // NOTE: this function *MUST* be called after using `validateTx` to check every
 // inside transaction is valid.
-func (p *poolContentSplitter) isTxBufferFull(t *types.Transaction, txs []*types.Transaction, gas uint64) bool {
-	if len(txs) >= int(p.maxTxPerBlock) {
+func (p *poolContentSplitter) isTxBufferFull(
+	t *types.Transaction,
+	txs []*types.Transaction,
+	gas uint64,
+	size *txBufferSize,
+	txSize uint64,
+) bool {
+	if len(txs) >= int(p.txLimit()) {
 		return true
 	}
 
-	if gas+t.Gas() > p.maxGasPerBlock {
+	if gas+t.Gas() > p.gasLimit() {
 		return true
 	}
 
-	// Transactions list's RLP encoding error has already been checked in
-	// `validateTx`, so no need to check the error here.
-	if b, _ := rlp.EncodeToBytes(append([]*types.Transaction{t}, txs...)); len(b) > int(p.maxTxBytesPerBlock) {
+	if rlp.ListSize(size.rlp+txSize) <= p.bytesLimit() {
+		return false
+	}
+
+	if p.txListCodec == encoding.TxListCodecNone {
 		return true
 	}
 
+	// Compressing the buffer again grows it at most by the uncompressed sizes of the transactions
+	// appended since the last compression, so only compress it when this estimate exceeds the limit.
+	if size.compressed != 0 &&
+		size.compressed+size.rlp+txSize-size.compressedRLP+compressionOverhead <= p.bytesLimit() {
+		return false
+	}
+
+	// Only compress the transactions list when the uncompressed one is too large. Transactions
+	// list's RLP encoding error has already been checked in `validateTx`, so no need to check
+	// the error here.
+	b, _ := rlp.EncodeToBytes(append(append([]*types.Transaction{}, txs...), t))
+	compressed, err := encoding.CompressTxListBytes(b, p.txListCodec)
+	if err != nil || len(compressed) > int(p.bytesLimit()) {
+		return true
+	}
+
+	size.compressed, size.compressedRLP = uint64(len(compressed)), size.rlp+txSize
+
 	return false
 }
 
-// weightedShuffle does a weighted shuffle for the given transactions, each transaction's
-// gas price will be used as the weight.
-func (p *poolContentSplitter) weightedShuffle(txLists []types.Transactions) []types.Transactions {
-	shuffled := make([]types.Transactions, 0)
+// gasLimit returns the gas limit of each transactions list, the operator target gas if configured.
+func (p *poolContentSplitter) gasLimit() uint64 {
+	if p.blockTargetGas != 0 {
+		return p.blockTargetGas
+	}
+	return p.maxGasPerBlock
+}
 
-	selector := utils.NewWeightedRandomSelect(func(i interface{}) uint64 {
-		var weight uint64 = 1
-		for _, tx := range txLists[i.(int)] {
-			weight += tx.GasPrice().Uint64()
-		}
-		return weight
-	})
+// txLimit returns the maximum number of transactions in each transactions list, the operator
+// limit if configured.
+func (p *poolContentSplitter) txLimit() uint64 {
+	if p.blockMaxTxs != 0 {
+		return p.blockMaxTxs
+	}
+	return p.maxTxPerBlock
+}
 
-	for i := range txLists {
-		selector.Update(i)
+// bytesLimit returns the maximum size of each transactions list, the operator limit if configured.
+func (p *poolContentSplitter) bytesLimit() uint64 {
+	if p.blockMaxBytes != 0 {
+		return p.blockMaxBytes
 	}
+	return p.maxTxBytesPerBlock
+}
 
-	for range txLists {
-		idx := selector.Choose().(int)
-		shuffled = append(shuffled, txLists[idx])
-		selector.Remove(idx)
+// deferUnderfilled removes the last transactions list from the given splitted lists, if its gas limit
+// is below the minimum fill level, so the proposer waits for more pending transactions to fill it in
+// the next epochs, unless too many epochs have deferred it in a row. The other lists are always full.
+// Returns the remaining lists and their bytes, and the deferred list, nil if not deferred.
+func (p *poolContentSplitter) deferUnderfilled(
+	txLists [][]*types.Transaction,
+	txListsBytes [][]byte,
+) ([][]*types.Transaction, [][]byte, []*types.Transaction) {
+	if p.minFillPercentage == 0 || len(txLists) == 0 {
+		return txLists, txListsBytes, nil
 	}
 
-	return shuffled
+	var (
+		last    = txLists[len(txLists)-1]
+		gas     = sumTxsGasLimit(last)
+		minFill = p.gasLimit() * p.minFillPercentage / 100
+	)
+	if gas >= minFill || len(last) >= int(p.txLimit()) {
+		p.deferredEpochs = 0
+		return txLists, txListsBytes, nil
+	}
+
+	if p.maxDeferredEpochs != 0 && p.deferredEpochs >= p.maxDeferredEpochs {
+		log.Info(
+			"Underfilled transactions list, but deferred too many epochs in a row, propose anyway",
+			"gas", gas,
+			"deferredEpochs", p.deferredEpochs,
+		)
+		p.deferredEpochs = 0
+		return txLists, txListsBytes, nil
+	}
+
+	p.deferredEpochs++
+	metrics.ProposerDeferredTxListsCounter.Inc(1)
+
+	log.Info(
+		"Defer underfilled transactions list, wait for more pending transactions",
+		"gas", gas,
+		"minFill", minFill,
+		"deferredEpochs", p.deferredEpochs,
+	)
+
+	return txLists[:len(txLists)-1], txListsBytes[:len(txListsBytes)-1], last
 }
@@ -1,12 +1,12 @@
 package proposer
 
 import (
-	"math/big"
-	"sort"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
@@ -19,7 +19,7 @@ func (s *ProposerTestSuite) TestPoolContentSplit() {
 		common.BytesToAddress(testutils.RandomBytes(32)): {
 			"0": types.NewTx(&types.LegacyTx{}),
 		},
-	})
+	}, nil)
 
 	s.Empty(splitted)
 
@@ -30,7 +30,7 @@ func (s *ProposerTestSuite) TestPoolContentSplit() {
 		common.BytesToAddress(testutils.RandomBytes(32)): {
 			"0": types.NewTx(&types.LegacyTx{Gas: 21001}),
 		},
-	})
+	}, nil)
 
 	s.Empty(splitted)
 
@@ -48,7 +48,7 @@ func (s *ProposerTestSuite) TestPoolContentSplit() {
 
 	splitted = splitter.split(rpc.PoolContent{
 		common.BytesToAddress(testutils.RandomBytes(32)): {"0": txBytesTooLarge},
-	})
+	}, nil)
 
 	s.Empty(splitted)
 
@@ -68,45 +68,154 @@ func (s *ProposerTestSuite) TestPoolContentSplit() {
 
 	splitted = splitter.split(rpc.PoolContent{
 		common.BytesToAddress(testutils.RandomBytes(32)): {"0": tx, "1": tx},
-	})
+	}, nil)
 
 	s.Equal(2, len(splitted))
 }
 
-func (s *ProposerTestSuite) TestWeightedShuffle() {
-	splitter := &poolContentSplitter{shufflePoolContent: true}
+func (s *ProposerTestSuite) TestPoolContentSplitWithCompression() {
+	var (
+		sender      = common.BytesToAddress(testutils.RandomBytes(20))
+		poolContent = rpc.PoolContent{sender: {}}
+		txs         []*types.Transaction
+	)
+	for i := 0; i < 16; i++ {
+		tx := types.NewTx(&types.LegacyTx{Nonce: uint64(i), Gas: 21000, Data: make([]byte, 256)})
+		poolContent[sender][strconv.Itoa(i)] = tx
+		txs = append(txs, tx)
+	}
 
-	txLists := make([]types.Transactions, 1024)
+	txListBytes, err := rlp.EncodeToBytes(txs)
+	s.Nil(err)
 
-	for i := 0; i < len(txLists); i++ {
-		var txList types.Transactions
-		for j := 0; j < 1024; j++ {
-			txList = append(txList, types.NewTx(&types.LegacyTx{Nonce: uint64(j), GasPrice: big.NewInt(int64(i))}))
-		}
-		txLists[i] = txList
+	splitter := &poolContentSplitter{
+		maxTxPerBlock:      uint64(len(txs)),
+		maxGasPerBlock:     21000 * uint64(len(txs)),
+		maxTxBytesPerBlock: uint64(len(txListBytes) / 2),
+		minTxGasLimit:      21000,
 	}
 
-	shuffled := splitter.weightedShuffle(txLists)
+	// Without compression, transactions can't be filled into a single list.
+	s.Greater(len(splitter.split(poolContent, nil)), 1)
 
-	// Whether is sorted
-	s.False(sort.SliceIsSorted(shuffled, func(i, j int) bool {
-		var (
-			gasA uint64 = 0
-			gasB uint64 = 0
-		)
+	splitter.txListCodec = encoding.TxListCodecZlib
+	splitted := splitter.split(poolContent, nil)
+	s.Equal(1, len(splitted))
 
-		for _, tx := range shuffled[i] {
-			gasA += tx.GasPrice().Uint64()
-		}
+	compressed, err := encodeTxList(splitted[0], splitter.txListCodec)
+	s.Nil(err)
+	s.LessOrEqual(len(compressed), int(splitter.maxTxBytesPerBlock))
 
-		for _, tx := range shuffled[j] {
-			gasB += tx.GasPrice().Uint64()
+	decompressed, err := encoding.DecompressTxListBytes(compressed)
+	s.Nil(err)
+	s.Equal(txListBytes, decompressed)
+
+	// Partly compressible transactions, every splitted list still fits the limit.
+	poolContent = rpc.PoolContent{sender: {}}
+	for i := 0; i < 64; i++ {
+		data := append(testutils.RandomBytes(64), make([]byte, 192)...)
+		poolContent[sender][strconv.Itoa(i)] = types.NewTx(
+			&types.LegacyTx{Nonce: uint64(i), Gas: 21000, Data: data},
+		)
+	}
+	splitter.maxTxPerBlock, splitter.maxGasPerBlock = 64, 21000*64
+
+	splitted = splitter.split(poolContent, nil)
+	s.Greater(len(splitted), 1)
+	for i, txs := range splitted {
+		encoded, err := encodeTxList(txs, splitter.txListCodec)
+		s.Nil(err)
+		s.LessOrEqual(len(encoded), int(splitter.maxTxBytesPerBlock))
+
+		// Every list but the last one is full.
+		if i != len(splitted)-1 {
+			next := append(append([]*types.Transaction{}, txs...), splitted[i+1][0])
+			encoded, err := encodeTxList(next, splitter.txListCodec)
+			s.Nil(err)
+			s.Greater(len(encoded), int(splitter.maxTxBytesPerBlock))
 		}
+	}
 
-		return gasA < gasB
-	}))
+	// Compressed bytes are only used when they are smaller.
+	incompressible := []*types.Transaction{types.NewTx(&types.LegacyTx{Data: testutils.RandomBytes(256)})}
+	incompressibleBytes, err := rlp.EncodeToBytes(incompressible)
+	s.Nil(err)
 
-	for _, txList := range shuffled {
-		s.True(sort.IsSorted(types.TxByNonce(txList)))
+	encoded, err := encodeTxList(incompressible, encoding.TxListCodecZlib)
+	s.Nil(err)
+	s.Equal(incompressibleBytes, encoded)
+}
+
+func (s *ProposerTestSuite) TestPoolContentSplitWithOperatorLimits() {
+	var (
+		sender      = common.BytesToAddress(testutils.RandomBytes(20))
+		poolContent = rpc.PoolContent{sender: {}}
+	)
+	for i := 0; i < 6; i++ {
+		poolContent[sender][strconv.Itoa(i)] = types.NewTx(&types.LegacyTx{Nonce: uint64(i), Gas: 21000})
+	}
+	poolContent[sender]["6"] = types.NewTx(&types.LegacyTx{Nonce: 6, Gas: 100000})
+
+	splitter := &poolContentSplitter{
+		maxTxPerBlock:      100,
+		maxGasPerBlock:     1000000,
+		maxTxBytesPerBlock: 120000,
+		minTxGasLimit:      21000,
+	}
+
+	// Protocol limits only.
+	s.Equal(1, len(splitter.split(poolContent, nil)))
+
+	// Operator target gas, the transaction beyond the target gas is proposed alone.
+	splitter.blockTargetGas = 21000 * 4
+	splitted := splitter.split(poolContent, nil)
+	s.Equal(3, len(splitted))
+	s.Equal(4, len(splitted[0]))
+	s.Equal(2, len(splitted[1]))
+	s.Equal(1, len(splitted[2]))
+
+	// Operator max transactions.
+	splitter.blockTargetGas, splitter.blockMaxTxs = 0, 2
+	s.Equal(4, len(splitter.split(poolContent, nil)))
+}
+
+func (s *ProposerTestSuite) TestDeferUnderfilled() {
+	var (
+		full        = []*types.Transaction{types.NewTx(&types.LegacyTx{Gas: 90000})}
+		underfilled = []*types.Transaction{types.NewTx(&types.LegacyTx{Gas: 21000})}
+		txLists     = [][]*types.Transaction{full, underfilled}
+		txBytes     = [][]byte{{0x01}, {0x02}}
+		splitter    = &poolContentSplitter{maxTxPerBlock: 10, maxGasPerBlock: 100000}
+	)
+
+	// Disabled.
+	lists, listsBytes, deferred := splitter.deferUnderfilled(txLists, txBytes)
+	s.Equal(txLists, lists)
+	s.Equal(txBytes, listsBytes)
+	s.Nil(deferred)
+
+	splitter.minFillPercentage, splitter.maxDeferredEpochs = 50, 2
+
+	for i := 0; i < 2; i++ {
+		lists, listsBytes, deferred = splitter.deferUnderfilled(txLists, txBytes)
+		s.Equal([][]*types.Transaction{full}, lists)
+		s.Equal([][]byte{{0x01}}, listsBytes)
+		s.Equal(underfilled, deferred)
 	}
+
+	// Deferred too many epochs in a row.
+	lists, _, deferred = splitter.deferUnderfilled(txLists, txBytes)
+	s.Equal(txLists, lists)
+	s.Nil(deferred)
+	s.Zero(splitter.deferredEpochs)
+
+	// Filled above the minimum fill level.
+	lists, _, deferred = splitter.deferUnderfilled([][]*types.Transaction{full}, [][]byte{{0x01}})
+	s.Equal([][]*types.Transaction{full}, lists)
+	s.Nil(deferred)
+
+	// Below the operator target gas's minimum fill level.
+	splitter.blockTargetGas = 200000
+	_, _, deferred = splitter.deferUnderfilled([][]*types.Transaction{full}, [][]byte{{0x01}})
+	s.Equal(full, deferred)
 }
@@ -0,0 +1,184 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+var (
+	// Default rough estimations of the execution gas used by TaikoL1.commitBlock and TaikoL1.proposeBlock
+	// transactions, intrinsic gas and calldata gas are not included.
+	defaultCommitBlockExecutionGas  uint64 = 50000
+	defaultProposeBlockExecutionGas uint64 = 250000
+)
+
+// l1ExecutionGas contains the estimations of the execution gas used by TaikoL1.commitBlock and
+// TaikoL1.proposeBlock transactions, intrinsic gas and calldata gas are not included.
+type l1ExecutionGas struct {
+	commitBlock  uint64
+	proposeBlock uint64
+}
+
+// newL1ExecutionGas creates a new l1ExecutionGas instance, the default estimations are used
+// if the given ones are zero.
+func newL1ExecutionGas(commitBlock uint64, proposeBlock uint64) l1ExecutionGas {
+	if commitBlock == 0 {
+		commitBlock = defaultCommitBlockExecutionGas
+	}
+	if proposeBlock == 0 {
+		proposeBlock = defaultProposeBlockExecutionGas
+	}
+
+	return l1ExecutionGas{commitBlock: commitBlock, proposeBlock: proposeBlock}
+}
+
+// profitabilityEstimator compares the estimated L1 cost of committing and proposing
+// the transactions lists with the L2 tips they will collect, and decides whether
+// the current proposing epoch should be skipped.
+type profitabilityEstimator struct {
+	minMargin        float64 // Minimum margin of L2 tips over the L1 cost, 0.1 means 10%
+	maxSkippedEpochs uint64  // Propose anyway after skipping these many epochs in a row, 0 means no limit
+	skippedEpochs    uint64  // Number of epochs skipped in a row
+
+	// The L2 transactions usually use less gas than their gas limits, so their gas used is estimated
+	// by discounting the gas limits, 0.2 means the gas used is 80% of the gas limit.
+	l2GasUsedDiscount float64
+	l1ExecutionGas    l1ExecutionGas
+}
+
+// txListCostEstimation contains the estimated L1 cost and L2 revenue of proposing a transactions list.
+type txListCostEstimation struct {
+	l1Gas     uint64
+	l1Cost    *big.Int
+	l2Revenue *big.Int
+}
+
+// isProfitable checks whether proposing the given estimations is profitable, if not,
+// the current epoch is counted as skipped, unless too many epochs have been skipped in a row.
+func (e *profitabilityEstimator) isProfitable(estimations []*txListCostEstimation) bool {
+	var (
+		l1Cost    = new(big.Int)
+		l2Revenue = new(big.Int)
+	)
+
+	for _, estimation := range estimations {
+		l1Cost.Add(l1Cost, estimation.l1Cost)
+		l2Revenue.Add(l2Revenue, estimation.l2Revenue)
+	}
+
+	minRevenue, _ := new(big.Float).Mul(
+		new(big.Float).SetInt(l1Cost),
+		big.NewFloat(1+e.minMargin),
+	).Int(nil)
+
+	if l2Revenue.Cmp(minRevenue) >= 0 {
+		e.skippedEpochs = 0
+		return true
+	}
+
+	if e.maxSkippedEpochs != 0 && e.skippedEpochs >= e.maxSkippedEpochs {
+		log.Info(
+			"Unprofitable proposing epoch, but too many epochs skipped in a row, propose anyway",
+			"l1Cost", l1Cost,
+			"l2Revenue", l2Revenue,
+			"skippedEpochs", e.skippedEpochs,
+		)
+		e.skippedEpochs = 0
+		return true
+	}
+
+	e.skippedEpochs++
+	metrics.ProposerSkippedEpochsCounter.Inc(1)
+
+	log.Info(
+		"Skip unprofitable proposing epoch",
+		"l1Cost", l1Cost,
+		"l2Revenue", l2Revenue,
+		"minMargin", e.minMargin,
+		"skippedEpochs", e.skippedEpochs,
+	)
+
+	return false
+}
+
+// estimateTxListCost estimates the L1 cost of committing and proposing the given transactions list,
+// and the L2 tips it will collect.
+func (e *profitabilityEstimator) estimateTxListCost(
+	txs types.Transactions,
+	txListBytes []byte,
+	l1GasPrice *big.Int,
+	l2BaseFee *big.Int,
+	withCommit bool,
+) (*txListCostEstimation, error) {
+	// Only the size of the encoded input matters here, so an empty metadata is used.
+	inputs, err := encoding.EncodeProposeBlockInput(
+		&bindings.LibDataBlockMetadata{Id: common.Big0, L1Height: common.Big0},
+		txListBytes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, err := encoding.TaikoL1ABI.Pack("proposeBlock", inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	l1Gas := params.TxGas + e.l1ExecutionGas.proposeBlock + calldataGas(calldata)
+	if withCommit {
+		l1Gas += params.TxGas + e.l1ExecutionGas.commitBlock
+	}
+
+	l2Revenue := new(big.Int)
+	for _, tx := range txs {
+		// The effective tip is negative when the fee cap is below the base fee, such a transaction
+		// collects nothing.
+		tip := tx.EffectiveGasTipValue(l2BaseFee)
+		if tip.Sign() <= 0 {
+			continue
+		}
+
+		gasUsed := uint64(float64(tx.Gas()) * (1 - e.l2GasUsedDiscount))
+		l2Revenue.Add(l2Revenue, new(big.Int).Mul(tip, new(big.Int).SetUint64(gasUsed)))
+	}
+
+	return &txListCostEstimation{
+		l1Gas:     l1Gas,
+		l1Cost:    new(big.Int).Mul(l1GasPrice, new(big.Int).SetUint64(l1Gas)),
+		l2Revenue: l2Revenue,
+	}, nil
+}
+
+// calldataGas calculates the intrinsic gas cost of the given transaction calldata.
+func calldataGas(data []byte) uint64 {
+	var gas uint64
+	for _, b := range data {
+		if b == 0 {
+			gas += params.TxDataZeroGas
+		} else {
+			gas += params.TxDataNonZeroGasEIP2028
+		}
+	}
+	return gas
+}
+
+// estimateL1GasPrice estimates the gas price of the L1 transactions which will be sent
+// in current proposing epoch, based on the latest L1 base fee and the fees suggested by
+// the proposer's fee strategy.
+func (p *Proposer) estimateL1GasPrice(ctx context.Context) (*big.Int, error) {
+	fees, err := p.feeStrategy.SuggestFees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest L1 transaction fees: %w", err)
+	}
+
+	return fees.EffectiveGasPrice(), nil
+}
@@ -0,0 +1,82 @@
+package proposer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func (s *ProposerTestSuite) TestIsProfitable() {
+	estimator := &profitabilityEstimator{minMargin: 0.1, maxSkippedEpochs: 2}
+
+	s.True(estimator.isProfitable([]*txListCostEstimation{
+		{l1Cost: big.NewInt(100), l2Revenue: big.NewInt(110)},
+	}))
+	s.Zero(estimator.skippedEpochs)
+
+	unprofitable := []*txListCostEstimation{
+		{l1Cost: big.NewInt(100), l2Revenue: big.NewInt(60)},
+		{l1Cost: big.NewInt(100), l2Revenue: big.NewInt(60)},
+	}
+
+	s.False(estimator.isProfitable(unprofitable))
+	s.False(estimator.isProfitable(unprofitable))
+	s.Equal(uint64(2), estimator.skippedEpochs)
+
+	// Too many epochs skipped in a row.
+	s.True(estimator.isProfitable(unprofitable))
+	s.Zero(estimator.skippedEpochs)
+}
+
+func (s *ProposerTestSuite) TestEstimateTxListCost() {
+	txs := types.Transactions{
+		newDynamicFeeTx(0, 2, 100),
+		newDynamicFeeTx(1, 50, 60),
+	}
+
+	txListBytes, err := rlp.EncodeToBytes(txs)
+	s.Nil(err)
+
+	var (
+		l2BaseFee = big.NewInt(20)
+		estimator = &profitabilityEstimator{l1ExecutionGas: newL1ExecutionGas(0, 0)}
+	)
+
+	withoutCommit, err := estimator.estimateTxListCost(txs, txListBytes, common10Gwei, l2BaseFee, false)
+	s.Nil(err)
+	s.Equal(big.NewInt((2+40)*21000), withoutCommit.l2Revenue)
+	s.Equal(new(big.Int).Mul(common10Gwei, new(big.Int).SetUint64(withoutCommit.l1Gas)), withoutCommit.l1Cost)
+
+	withCommit, err := estimator.estimateTxListCost(txs, txListBytes, common10Gwei, l2BaseFee, true)
+	s.Nil(err)
+	s.Equal(withoutCommit.l1Gas+params.TxGas+defaultCommitBlockExecutionGas, withCommit.l1Gas)
+
+	// The gas used is estimated by discounting the gas limits, and the L1 execution gas is configurable.
+	estimator = &profitabilityEstimator{l2GasUsedDiscount: 0.5, l1ExecutionGas: newL1ExecutionGas(1000, 2000)}
+	discounted, err := estimator.estimateTxListCost(txs, txListBytes, common10Gwei, l2BaseFee, true)
+	s.Nil(err)
+	s.Equal(big.NewInt((2+40)*10500), discounted.l2Revenue)
+	s.Equal(
+		withCommit.l1Gas-defaultCommitBlockExecutionGas-defaultProposeBlockExecutionGas+1000+2000,
+		discounted.l1Gas,
+	)
+
+	// A transaction whose fee cap is below the base fee collects no tips.
+	underpriced := append(txs, newDynamicFeeTx(2, 10, 15))
+	txListBytes, err = rlp.EncodeToBytes(underpriced)
+	s.Nil(err)
+
+	estimator = &profitabilityEstimator{l1ExecutionGas: newL1ExecutionGas(0, 0)}
+	withUnderpriced, err := estimator.estimateTxListCost(underpriced, txListBytes, common10Gwei, l2BaseFee, false)
+	s.Nil(err)
+	s.Equal(big.NewInt((2+40)*21000), withUnderpriced.l2Revenue)
+}
+
+func (s *ProposerTestSuite) TestCalldataGas() {
+	s.Equal(uint64(0), calldataGas([]byte{}))
+	s.Equal(params.TxDataZeroGas+params.TxDataNonZeroGasEIP2028, calldataGas([]byte{0x00, 0x01}))
+}
+
+var common10Gwei = big.NewInt(10 * params.GWei)
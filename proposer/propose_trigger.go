@@ -0,0 +1,175 @@
+package proposer
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+// proposeTrigger decides when a proposing epoch should start besides the regular proposing
+// interval. It watches the L2 node's new pending transactions, and starts an epoch early once
+// the pending transactions reach the gas or bytes threshold, it also skips the interval ticks
+// which would only propose almost empty blocks.
+type proposeTrigger struct {
+	gasThreshold    uint64 // Propose early once the pending transactions' gas limit reaches this, 0 to disable
+	bytesThreshold  uint64 // Propose early once the pending transactions' size reaches this, 0 to disable
+	minGas          uint64 // Skip the interval ticks whose pending transactions' gas limit is below this
+	maxSkippedTicks uint64 // Propose anyway after skipping these many ticks in a row, 0 means no limit
+	skippedTicks    uint64 // Number of ticks skipped in a row, only accessed by the event loop
+
+	mu           sync.Mutex
+	signer       types.Signer
+	pendingTxs   map[pendingTxKey]*types.Transaction // Replacement transactions are only counted once
+	pendingGas   uint64                              // Gas limit of the pending transactions not proposed yet
+	pendingBytes uint64                              // Size of the pending transactions not proposed yet
+	notifyCh     chan struct{}
+}
+
+// pendingTxKey identifies a pending transaction by its sender and nonce, or by its hash if
+// the sender can't be recovered.
+type pendingTxKey struct {
+	sender common.Address
+	nonce  uint64
+	hash   common.Hash
+}
+
+// newProposeTrigger creates a new proposeTrigger instance, the given signer is used to recover
+// the pending transactions' senders.
+func newProposeTrigger(
+	signer types.Signer,
+	gasThreshold, bytesThreshold, minGas, maxSkippedTicks uint64,
+) *proposeTrigger {
+	return &proposeTrigger{
+		gasThreshold:    gasThreshold,
+		bytesThreshold:  bytesThreshold,
+		minGas:          minGas,
+		maxSkippedTicks: maxSkippedTicks,
+		signer:          signer,
+		pendingTxs:      make(map[pendingTxKey]*types.Transaction),
+		notifyCh:        make(chan struct{}, 1),
+	}
+}
+
+// enabled returns whether the new pending transactions should be watched.
+func (t *proposeTrigger) enabled() bool {
+	return t.gasThreshold != 0 || t.bytesThreshold != 0
+}
+
+// onPendingTx accumulates a new pending transaction, and notifies the event loop once
+// the threshold is reached. A replacement transaction takes the place of the replaced one.
+func (t *proposeTrigger) onPendingTx(tx *types.Transaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.add(tx)
+
+	if (t.gasThreshold != 0 && t.pendingGas >= t.gasThreshold) ||
+		(t.bytesThreshold != 0 && t.pendingBytes >= t.bytesThreshold) {
+		select {
+		case t.notifyCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// reset sets the accumulated pending transactions to the given transactions lists, which
+// are fetched from the L2 node but not proposed in the current epoch.
+func (t *proposeTrigger) reset(txLists [][]*types.Transaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pendingTxs = make(map[pendingTxKey]*types.Transaction)
+	t.pendingGas, t.pendingBytes = 0, 0
+	for _, txs := range txLists {
+		for _, tx := range txs {
+			t.add(tx)
+		}
+	}
+}
+
+// add accumulates the given pending transaction, replacing the accumulated one with the same
+// sender and nonce. The caller must hold the lock.
+func (t *proposeTrigger) add(tx *types.Transaction) {
+	key := pendingTxKey{nonce: tx.Nonce()}
+	if sender, err := types.Sender(t.signer, tx); err == nil {
+		key.sender = sender
+	} else {
+		key.hash = tx.Hash()
+	}
+
+	if replaced, ok := t.pendingTxs[key]; ok {
+		t.pendingGas -= replaced.Gas()
+		t.pendingBytes -= uint64(replaced.Size())
+	}
+
+	t.pendingTxs[key] = tx
+	t.pendingGas += tx.Gas()
+	t.pendingBytes += uint64(tx.Size())
+}
+
+// pending returns the accumulated gas limit of the pending transactions not proposed yet.
+func (t *proposeTrigger) pending() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.pendingGas
+}
+
+// shouldSkipTick checks whether an interval tick with the given pending transactions' gas limit
+// should be skipped, unless too many ticks have been skipped in a row.
+func (t *proposeTrigger) shouldSkipTick(gas uint64) bool {
+	if gas != 0 && gas >= t.minGas {
+		t.skippedTicks = 0
+		return false
+	}
+
+	// Skipping the empty ticks is not worth an info log if the trigger is not configured.
+	logFn := log.Info
+	if !t.enabled() && t.minGas == 0 {
+		logFn = log.Debug
+	}
+
+	if t.maxSkippedTicks != 0 && t.skippedTicks >= t.maxSkippedTicks {
+		logFn("Too many ticks skipped in a row, propose anyway", "gas", gas, "skippedTicks", t.skippedTicks)
+		t.skippedTicks = 0
+		return false
+	}
+
+	t.skippedTicks++
+	metrics.ProposerSkippedTicksCounter.Inc(1)
+
+	logFn("Skip almost empty proposing tick", "gas", gas, "minGas", t.minGas, "skippedTicks", t.skippedTicks)
+
+	return true
+}
+
+// startPendingTxsSubscription feeds the L2 node's new pending transactions into the proposeTrigger.
+func (p *Proposer) startPendingTxsSubscription() {
+	var (
+		pendingTxsCh = make(chan []*types.Transaction, 128)
+		sub          = p.pendingTxsWatcher.subscribe(pendingTxsCh)
+	)
+
+	p.pendingTxsWatcher.start(p.ctx)
+
+	go func() {
+		defer func() {
+			sub.Unsubscribe()
+			p.wg.Done()
+		}()
+
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case txs := <-pendingTxsCh:
+				for _, tx := range txs {
+					p.proposeTrigger.onPendingTx(tx)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,103 @@
+package proposer
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func (s *ProposerTestSuite) TestProposeTriggerThresholds() {
+	var (
+		tx     = newDynamicFeeTx(0, 1, 100)
+		signer = types.LatestSignerForChainID(common.Big1)
+	)
+
+	// Gas threshold
+	trigger := newProposeTrigger(signer, 2*tx.Gas(), 0, 0, 0)
+	s.True(trigger.enabled())
+
+	trigger.onPendingTx(newDynamicFeeTx(0, 1, 100))
+	s.Zero(len(trigger.notifyCh))
+	trigger.onPendingTx(newDynamicFeeTx(1, 1, 100))
+	s.Equal(1, len(trigger.notifyCh))
+
+	// Notifications are merged if the event loop is busy.
+	trigger.onPendingTx(newDynamicFeeTx(2, 1, 100))
+	s.Equal(1, len(trigger.notifyCh))
+	s.Equal(3*tx.Gas(), trigger.pending())
+
+	// Only the transactions not proposed in the epoch are kept.
+	<-trigger.notifyCh
+	trigger.reset([][]*types.Transaction{{tx}})
+	s.Equal(tx.Gas(), trigger.pending())
+	trigger.onPendingTx(newDynamicFeeTx(1, 1, 100))
+	s.Equal(1, len(trigger.notifyCh))
+
+	// Bytes threshold
+	trigger = newProposeTrigger(signer, 0, uint64(tx.Size())+1, 0, 0)
+	s.True(trigger.enabled())
+
+	trigger.onPendingTx(newDynamicFeeTx(0, 1, 100))
+	s.Zero(len(trigger.notifyCh))
+	trigger.onPendingTx(newDynamicFeeTx(1, 1, 100))
+	s.Equal(1, len(trigger.notifyCh))
+
+	s.False(newProposeTrigger(signer, 0, 0, 0, 0).enabled())
+}
+
+func (s *ProposerTestSuite) TestProposeTriggerReplacementTxs() {
+	var (
+		signer = types.LatestSignerForChainID(common.Big1)
+		signTx = func(nonce uint64, gas uint64, key *ecdsa.PrivateKey) *types.Transaction {
+			tx, err := types.SignTx(
+				types.NewTx(&types.DynamicFeeTx{ChainID: common.Big1, Nonce: nonce, Gas: gas, GasFeeCap: common.Big1}),
+				signer,
+				key,
+			)
+			s.Nil(err)
+			return tx
+		}
+		trigger = newProposeTrigger(signer, 100000, 0, 0, 0)
+	)
+
+	keyA, err := crypto.GenerateKey()
+	s.Nil(err)
+	keyB, err := crypto.GenerateKey()
+	s.Nil(err)
+
+	// A replacement transaction only counts once, with its own gas limit.
+	trigger.onPendingTx(signTx(0, 21000, keyA))
+	trigger.onPendingTx(signTx(0, 30000, keyA))
+	s.Equal(uint64(30000), trigger.pending())
+
+	// Same nonce from another sender.
+	trigger.onPendingTx(signTx(0, 21000, keyB))
+	s.Equal(uint64(51000), trigger.pending())
+
+	trigger.reset([][]*types.Transaction{{signTx(1, 21000, keyA)}})
+	trigger.onPendingTx(signTx(1, 40000, keyA))
+	s.Equal(uint64(40000), trigger.pending())
+	s.Zero(len(trigger.notifyCh))
+}
+
+func (s *ProposerTestSuite) TestProposeTriggerSkipTicks() {
+	signer := types.LatestSignerForChainID(common.Big1)
+	trigger := newProposeTrigger(signer, 0, 0, 0, 0)
+
+	// Empty ticks are always skipped.
+	s.True(trigger.shouldSkipTick(0))
+	s.False(trigger.shouldSkipTick(1))
+
+	trigger = newProposeTrigger(signer, 0, 0, 100, 2)
+
+	s.True(trigger.shouldSkipTick(99))
+	s.True(trigger.shouldSkipTick(0))
+	// Too many ticks skipped in a row.
+	s.False(trigger.shouldSkipTick(99))
+
+	s.True(trigger.shouldSkipTick(99))
+	s.False(trigger.shouldSkipTick(100))
+	s.Zero(trigger.skippedTicks)
+}
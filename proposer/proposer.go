@@ -2,23 +2,27 @@ package proposer
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/metrics"
+	feeStrategy "github.com/taikoxyz/taiko-client/pkg/fee_strategy"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+	txManager "github.com/taikoxyz/taiko-client/pkg/tx_manager"
 	"github.com/urfave/cli/v2"
 )
 
@@ -27,21 +31,63 @@ type Proposer struct {
 	// RPC clients
 	rpc *rpc.Client
 
-	// Private keys and account addresses
-	l1ProposerPrivKey       *ecdsa.PrivateKey
-	l2SuggestedFeeRecipient common.Address
+	// L1 transactions sender, which manages the nonces and replaces the stuck transactions
+	txMgr       *txManager.TxManager
+	feeStrategy *feeStrategy.FeeStrategy
+
+	// Signers and account addresses
+	l1ProposerSigner     signer.Signer
+	feeRecipientSelector *feeRecipientSelector
 
 	// Proposing configuration
-	proposingInterval time.Duration
-	commitSlot        uint64
+	proposingInterval   time.Duration
+	commitSlotAllocator *commitSlotAllocator
 
 	// Constants in LibConstants
 	commitDelayConfirmations uint64
+	maxPendingBlocks         uint64
 	poolContentSplitter      *poolContentSplitter
+	txSource                 TxSource
+	txListPreValidator       *txListPreValidator
+
+	// Drop the pending transactions which would fail on nonce or balance before proposing
+	precheckTxLists bool
+
+	// Committed transactions lists waiting for the commit delay confirmations to be proposed,
+	// bounded by maxPendingBlocks, so the next epoch can commit without waiting for them.
+	commitTxListResQueue chan *commitTxListRes
+	inFlightCommits      []*commitTxListRes // Committed but not yet proposed, including the proposing one
+	inFlightCommitsMu    sync.RWMutex
+
+	// Runtime controls through the admin RPC server
+	adminRPCAddr     string
+	adminJWTSecret   []byte
+	paused           int32 // Skip the proposing epochs if not zero
+	intervalUpdateCh chan time.Duration
+	proposeNowCh     chan chan error
+
+	// Proposes early once enough pending transactions arrive, and skips the almost empty ticks
+	proposeTrigger *proposeTrigger
+	// New pending transactions subscription shared by the streaming source and the proposeTrigger
+	pendingTxsWatcher *pendingTxsWatcher
+
+	// Commits and proposes the transactions lists again if their propose transactions are reorged
+	// out before having these many L1 confirmations, 0 to disable
+	reorgConfirmations uint64
+
+	// Tracks the L1 proposer balance, and pauses proposing while it's low if configured
+	balanceWatchdog *balanceWatchdog
+
+	// Only propose when the L2 tips can cover the L1 cost, nil if disabled
+	profitabilityEstimator *profitabilityEstimator
+	l1ExecutionGas         l1ExecutionGas // Used when the L1 cost can't be estimated by the L1 node
+
+	// Only simulate the proposals without sending any L1 transaction
+	dryRun         bool
+	taikoL1Address common.Address
 
-	// Flags for testing
-	produceInvalidBlocks         bool
-	produceInvalidBlocksInterval uint64
+	// Proposes the faulty transactions lists on schedule, only for testing
+	faultInjector *faultInjector
 
 	// Only for testing purposes
 	AfterCommitHook func() error
@@ -64,9 +110,16 @@ func (p *Proposer) InitFromCli(ctx context.Context, c *cli.Context) error {
 func InitFromConfig(ctx context.Context, p *Proposer, cfg *Config) (err error) {
 	log.Debug("Proposer configurations", "config", cfg)
 
-	p.l1ProposerPrivKey = cfg.L1ProposerPrivKey
-	p.l2SuggestedFeeRecipient = cfg.L2SuggestedFeeRecipient
+	p.l1ProposerSigner = cfg.L1ProposerSigner
 	p.proposingInterval = cfg.ProposeInterval
+	p.precheckTxLists = cfg.PrecheckTxLists
+	p.reorgConfirmations = cfg.ReorgConfirmations
+	p.dryRun = cfg.DryRun
+	p.adminRPCAddr = cfg.AdminRPCAddr
+	p.adminJWTSecret = cfg.AdminJWTSecret
+	p.intervalUpdateCh = make(chan time.Duration)
+	p.proposeNowCh = make(chan chan error)
+	p.taikoL1Address = cfg.TaikoL1Address
 	p.wg = sync.WaitGroup{}
 	p.ctx = ctx
 
@@ -81,7 +134,7 @@ func InitFromConfig(ctx context.Context, p *Proposer, cfg *Config) (err error) {
 	}
 
 	// Protocol constants
-	_, _, _, _, commitDelayConfirmations, _,
+	_, _, maxPendingBlocks, _, commitDelayConfirmations, _,
 		maxGasPerBlock, maxTxPerBlock, _, maxTxBytesPerBlock, minTxGasLimit,
 		_, _, _, err := p.rpc.TaikoL1.GetConstants(nil)
 	if err != nil {
@@ -91,33 +144,163 @@ func InitFromConfig(ctx context.Context, p *Proposer, cfg *Config) (err error) {
 	log.Info(
 		"Protocol constants",
 		"commitDelayConfirmations", commitDelayConfirmations,
+		"maxPendingBlocks", maxPendingBlocks,
 		"maxTxPerBlock", maxTxPerBlock,
 		"maxGasPerBlock", maxGasPerBlock,
 		"maxTxBytesPerBlock", maxTxBytesPerBlock,
 		"minTxGasLimit", minTxGasLimit,
 	)
 
+	if err := cfg.validateBlockLimits(
+		maxGasPerBlock.Uint64(),
+		maxTxPerBlock.Uint64(),
+		maxTxBytesPerBlock.Uint64(),
+	); err != nil {
+		return err
+	}
+
+	if p.feeRecipientSelector, err = newFeeRecipientSelector(
+		cfg.FeeRecipientRotation,
+		cfg.L2SuggestedFeeRecipients,
+	); err != nil {
+		return err
+	}
+
+	txOrderingStrategy, err := NewTxOrderingStrategy(cfg.TxOrderingStrategy)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Transaction ordering strategy", "name", txOrderingStrategy.Name())
+
+	// The shuffle replaces the default ordering, so it is only applied when no other strategy is set.
+	shufflePoolContent := cfg.ShufflePoolContent
+	if shufflePoolContent && txOrderingStrategy.Name() != TxOrderingSenderNonce {
+		log.Warn("Pool content shuffle is ignored by the transaction ordering strategy", "name", txOrderingStrategy.Name())
+		shufflePoolContent = false
+	}
+
+	p.pendingTxsWatcher = newPendingTxsWatcher(p.rpc)
+	if p.txSource, err = NewTxSource(cfg.TxSource, p.rpc, p.pendingTxsWatcher); err != nil {
+		return err
+	}
+
+	log.Info("Pending transactions source", "name", p.txSource.Name())
+
+	var (
+		senderLists              *senderLists
+		priorityOrderingStrategy TxOrderingStrategy
+	)
+	if cfg.SenderListsFile != "" {
+		if senderLists, err = newSenderLists(cfg.SenderListsFile); err != nil {
+			return err
+		}
+		if priorityOrderingStrategy, err = NewTxOrderingStrategy(cfg.TxOrderingStrategy); err != nil {
+			return err
+		}
+	}
+
 	p.commitDelayConfirmations = commitDelayConfirmations.Uint64()
+	p.maxPendingBlocks = maxPendingBlocks.Uint64()
+	p.commitTxListResQueue = make(chan *commitTxListRes, p.maxPendingBlocks)
 	p.poolContentSplitter = &poolContentSplitter{
-		shufflePoolContent: cfg.ShufflePoolContent,
+		shufflePoolContent: shufflePoolContent,
+		txOrderingStrategy: txOrderingStrategy,
+		senderLists:        senderLists,
+		priorityOrdering:   priorityOrderingStrategy,
+		txListCodec:        cfg.TxListCodec,
 		maxTxPerBlock:      maxTxPerBlock.Uint64(),
 		maxGasPerBlock:     maxGasPerBlock.Uint64(),
 		maxTxBytesPerBlock: maxTxBytesPerBlock.Uint64(),
 		minTxGasLimit:      minTxGasLimit.Uint64(),
+		blockTargetGas:     cfg.BlockTargetGas,
+		blockMaxTxs:        cfg.BlockMaxTxs,
+		blockMaxBytes:      cfg.BlockMaxBytes,
+		minFillPercentage:  cfg.BlockMinFillPercentage,
+		maxDeferredEpochs:  cfg.BlockMaxDeferredEpochs,
+	}
+	p.txListPreValidator = newTxListPreValidator(txListValidator.NewTxListValidator(
+		maxGasPerBlock.Uint64(),
+		maxTxPerBlock.Uint64(),
+		maxTxBytesPerBlock.Uint64(),
+		minTxGasLimit.Uint64(),
+		p.rpc.L2ChainID,
+		cfg.CompressedTxLists,
+	))
+	p.commitSlotAllocator = newCommitSlotAllocator(
+		cfg.CommitSlot,
+		p.l1ProposerSigner.Address(),
+		p.commitDelayConfirmations,
+		cfg.CommitSlotExpiry,
+	)
+	p.feeStrategy = feeStrategy.New(p.rpc.L1, cfg.FeeStrategy)
+	p.txMgr = txManager.New(p.rpc.L1, func(ctx context.Context) (*bind.TransactOpts, error) {
+		return getTxOpts(ctx, p.feeStrategy, p.l1ProposerSigner, p.rpc.L1ChainID)
+	}, &txManager.Config{
+		ResubmissionTimeout:  cfg.TxResubmissionTimeout,
+		FeeBumpPercentage:    cfg.TxFeeBumpPercentage,
+		MaxGasFeeCap:         cfg.FeeStrategy.MaxGasFeeCap,
+		ReceiptQueryInterval: time.Second,
+	})
+
+	p.proposeTrigger = newProposeTrigger(
+		types.LatestSignerForChainID(p.rpc.L2ChainID),
+		cfg.TriggerGasThreshold,
+		cfg.TriggerBytesThreshold,
+		cfg.TriggerMinGas,
+		cfg.TriggerMaxSkippedTicks,
+	)
+
+	p.l1ExecutionGas = newL1ExecutionGas(cfg.CommitBlockExecutionGas, cfg.ProposeBlockExecutionGas)
+
+	p.balanceWatchdog = newBalanceWatchdog(cfg.BalanceWarnThreshold, cfg.BalanceWarnEpochs, cfg.PauseOnLowBalance)
+
+	if cfg.CheckProfitability {
+		p.profitabilityEstimator = &profitabilityEstimator{
+			minMargin:         cfg.MinProfitMargin,
+			maxSkippedEpochs:  cfg.MaxSkippedEpochs,
+			l2GasUsedDiscount: cfg.L2GasUsedDiscount,
+			l1ExecutionGas:    p.l1ExecutionGas,
+		}
 	}
-	p.commitSlot = cfg.CommitSlot
 
 	// Configurations for testing
-	p.produceInvalidBlocks = cfg.ProduceInvalidBlocks
-	p.produceInvalidBlocksInterval = cfg.ProduceInvalidBlocksInterval
+	if cfg.InvalidBlocksInterval != 0 {
+		log.Warn("Flag produceInvalidBlocks is deprecated, use faultInjection.config instead")
+	}
+
+	if p.faultInjector, err = newFaultInjector(
+		cfg.FaultInjectionConfigFile,
+		cfg.InvalidBlocksInterval,
+		p.poolContentSplitter,
+		p.rpc.L2ChainID,
+	); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 // Start starts the proposer's main loop.
 func (p *Proposer) Start() error {
-	p.wg.Add(1)
+	if p.adminRPCAddr != "" {
+		if err := p.startAdminRPCServer(); err != nil {
+			return fmt.Errorf("failed to start admin RPC server: %w", err)
+		}
+	}
+
+	if err := p.txSource.Start(p.ctx); err != nil {
+		return fmt.Errorf("failed to start pending transactions source: %w", err)
+	}
+
+	if p.proposeTrigger.enabled() {
+		p.wg.Add(1)
+		p.startPendingTxsSubscription()
+	}
+
+	p.wg.Add(2)
 	go p.eventLoop()
+	go p.proposeLoop()
 	return nil
 }
 
@@ -133,20 +316,74 @@ func (p *Proposer) eventLoop() {
 		select {
 		case <-p.ctx.Done():
 			return
+		case interval := <-p.intervalUpdateCh:
+			log.Info("Proposing interval updated", "old", p.proposingInterval, "new", interval)
+			// Also read by the admin RPC server.
+			atomic.StoreInt64((*int64)(&p.proposingInterval), int64(interval))
+			ticker.Reset(interval)
+		case errCh := <-p.proposeNowCh:
+			metrics.ProposerProposeEpochCounter.Inc(1)
+			errCh <- p.commitAndEnqueueOp(p.ctx, false)
+		case <-p.proposeTrigger.notifyCh:
+			if atomic.LoadInt32(&p.paused) != 0 || p.balanceWatchdog.paused() {
+				continue
+			}
+
+			log.Info("Pending transactions reached the threshold, propose early")
+			metrics.ProposerProposeEpochCounter.Inc(1)
+			metrics.ProposerTriggeredEpochsCounter.Inc(1)
+
+			// The next tick comes a full interval after this epoch.
+			ticker.Reset(p.proposingInterval)
+
+			if err := p.commitAndEnqueueOp(p.ctx, false); err != nil {
+				log.Error("Committing operation error", "error", err)
+			}
 		case <-ticker.C:
+			if err := p.checkBalanceOp(p.ctx); err != nil {
+				log.Error("Checking balance operation error", "error", err)
+			}
+
+			if atomic.LoadInt32(&p.paused) != 0 {
+				log.Info("Proposer is paused, skip proposing epoch")
+				continue
+			}
+
+			if p.balanceWatchdog.paused() {
+				log.Warn("L1 proposer balance is low, skip proposing epoch")
+				continue
+			}
+
 			metrics.ProposerProposeEpochCounter.Inc(1)
 
-			if err := p.ProposeOp(p.ctx); err != nil {
-				log.Error("Proposing operation error", "error", err)
+			if err := p.commitAndEnqueueOp(p.ctx, true); err != nil {
+				log.Error("Committing operation error", "error", err)
 				continue
 			}
 
 			// Only for testing purposes
-			if p.produceInvalidBlocks && p.produceInvalidBlocksInterval > 0 {
-				if err := p.ProposeInvalidBlocksOp(p.ctx, p.produceInvalidBlocksInterval); err != nil {
-					log.Error("Proposing invalid blocks operation error", "error", err)
-				}
+			if err := p.InjectFaultsOp(p.ctx); err != nil {
+				log.Error("Fault injection operation error", "error", err)
+			}
+		}
+	}
+}
+
+// proposeLoop keeps proposing the committed transactions lists in the in-flight queue,
+// in the same order as they were committed.
+func (p *Proposer) proposeLoop() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case commitRes := <-p.commitTxListResQueue:
+			if err := p.ProposeTxList(p.ctx, commitRes); err != nil {
+				log.Error("Proposing transactions list error", "commitSlot", commitRes.meta.CommitSlot, "error", err)
 			}
+
+			p.removeInFlightCommit(commitRes)
 		}
 	}
 }
@@ -154,6 +391,8 @@ func (p *Proposer) eventLoop() {
 // Close closes the proposer instance.
 func (p *Proposer) Close() {
 	p.wg.Wait()
+	p.txSource.Close()
+	p.pendingTxsWatcher.close()
 }
 
 type commitTxListRes struct {
@@ -161,36 +400,267 @@ type commitTxListRes struct {
 	commitTx    *types.Transaction
 	txListBytes []byte
 	txNum       uint
+	// Highest nonce of each sender's transactions in the list, the pending transactions up to
+	// these nonces are excluded from the later epochs while the list is in flight.
+	nonces map[common.Address]uint64
 }
 
 // ProposeOp performs a proposing operation, fetching transactions
 // from L2 node's tx pool, splitting them by proposing constraints,
 // and then proposing them to TaikoL1 contract.
 func (p *Proposer) ProposeOp(ctx context.Context) error {
+	// Still propose the committed transactions lists if only a part of them are committed.
+	commitTxListResQueue, commitErr := p.commitOp(ctx, 0, false)
+
+	for _, commitTxListRes := range commitTxListResQueue {
+		if err := p.ProposeTxList(ctx, commitTxListRes); err != nil {
+			return fmt.Errorf("failed to propose transactions: %w", err)
+		}
+	}
+
+	return commitErr
+}
+
+// commitAndEnqueueOp performs a committing operation, and then pushes the committed
+// transactions lists into the in-flight queue, which will be proposed by the proposeLoop
+// after the commit delay confirmations, without blocking the next proposing epoch.
+// If `isTick` is true, the epoch is started by the proposing interval ticker, and may be
+// skipped by the proposeTrigger, or defer its underfilled transactions list. Otherwise the
+// epoch is forced, e.g. by the admin RPC server or the proposeTrigger, and proposes all lists.
+func (p *Proposer) commitAndEnqueueOp(ctx context.Context, isTick bool) error {
+	inFlightCommits := len(p.getInFlightCommits())
+	if inFlightCommits >= int(p.maxPendingBlocks) {
+		log.Info("Too many in-flight commits, skip committing", "inFlight", inFlightCommits)
+		return nil
+	}
+
+	// Still enqueue the committed transactions lists if only a part of them are committed.
+	commitTxListResQueue, err := p.commitOp(ctx, int(p.maxPendingBlocks)-inFlightCommits, isTick)
+
+	// Only the event loop pushes into the queue after checking the in-flight commits, so there is
+	// always enough space here.
+	for _, commitTxListRes := range commitTxListResQueue {
+		p.addInFlightCommit(commitTxListRes)
+		p.commitTxListResQueue <- commitTxListRes
+	}
+
+	return err
+}
+
+// getInFlightCommits returns all committed but not yet proposed transactions lists.
+func (p *Proposer) getInFlightCommits() []*commitTxListRes {
+	p.inFlightCommitsMu.RLock()
+	defer p.inFlightCommitsMu.RUnlock()
+
+	return append([]*commitTxListRes{}, p.inFlightCommits...)
+}
+
+// addInFlightCommit adds a committed transactions list to the in-flight commits.
+func (p *Proposer) addInFlightCommit(commitRes *commitTxListRes) {
+	p.inFlightCommitsMu.Lock()
+	defer p.inFlightCommitsMu.Unlock()
+
+	p.inFlightCommits = append(p.inFlightCommits, commitRes)
+	metrics.ProposerInFlightCommitsGauge.Update(int64(len(p.inFlightCommits)))
+}
+
+// removeInFlightCommit removes a proposed transactions list from the in-flight commits.
+func (p *Proposer) removeInFlightCommit(commitRes *commitTxListRes) {
+	p.inFlightCommitsMu.Lock()
+	defer p.inFlightCommitsMu.Unlock()
+
+	for i, inFlight := range p.inFlightCommits {
+		if inFlight == commitRes {
+			p.inFlightCommits = append(p.inFlightCommits[:i], p.inFlightCommits[i+1:]...)
+			break
+		}
+	}
+	metrics.ProposerInFlightCommitsGauge.Update(int64(len(p.inFlightCommits)))
+}
+
+// inFlightNonces returns the highest nonce of each sender's transactions in all in-flight commits.
+func (p *Proposer) inFlightNonces() map[common.Address]uint64 {
+	p.inFlightCommitsMu.RLock()
+	defer p.inFlightCommitsMu.RUnlock()
+
+	nonces := make(map[common.Address]uint64)
+	for _, commitRes := range p.inFlightCommits {
+		for sender, nonce := range commitRes.nonces {
+			if highest, ok := nonces[sender]; !ok || nonce > highest {
+				nonces[sender] = nonce
+			}
+		}
+	}
+
+	return nonces
+}
+
+// excludeInFlightTxs returns a copy of the given pool content without the transactions whose nonces
+// are not higher than their senders' in-flight nonces, i.e. the already committed transactions and
+// their replacements.
+func excludeInFlightTxs(poolContent rpc.PoolContent, nonces map[common.Address]uint64) rpc.PoolContent {
+	if len(nonces) == 0 {
+		return poolContent
+	}
+
+	excluded := make(rpc.PoolContent, len(poolContent))
+	for sender, txs := range poolContent {
+		highest, ok := nonces[sender]
+		if !ok {
+			excluded[sender] = txs
+			continue
+		}
+
+		senderTxs := make(map[string]*types.Transaction, len(txs))
+		for key, tx := range txs {
+			if tx.Nonce() > highest {
+				senderTxs[key] = tx
+			}
+		}
+
+		if len(senderTxs) != 0 {
+			excluded[sender] = senderTxs
+		}
+	}
+
+	return excluded
+}
+
+// txListNonces returns the highest nonce of each sender's transactions in the given list, the
+// senders are looked up by transaction hashes.
+func txListNonces(senders map[common.Hash]common.Address, txs []*types.Transaction) map[common.Address]uint64 {
+	nonces := make(map[common.Address]uint64)
+	for _, tx := range txs {
+		sender, ok := senders[tx.Hash()]
+		if !ok {
+			continue
+		}
+
+		if highest, ok := nonces[sender]; !ok || tx.Nonce() > highest {
+			nonces[sender] = tx.Nonce()
+		}
+	}
+
+	return nonces
+}
+
+// commitOp fetches transactions from L2 node's tx pool, splits them by proposing constraints,
+// and then commits at most `maxTxLists` transactions lists (0 means no limit) to TaikoL1 contract.
+// If committing a transactions list fails, the ones committed before it are returned together
+// with the error.
+func (p *Proposer) commitOp(ctx context.Context, maxTxLists int, isTick bool) ([]*commitTxListRes, error) {
 	syncProgress, err := p.rpc.L2.SyncProgress(ctx)
 	if err != nil || syncProgress != nil {
-		return fmt.Errorf("l2 node is syncing: %w, syncProgress: %v", err, syncProgress)
+		return nil, fmt.Errorf("l2 node is syncing: %w, syncProgress: %v", err, syncProgress)
 	}
 
 	log.Info("Start fetching L2 node's transaction pool content")
 
-	pendingContent, _, err := p.rpc.L2PoolContent(ctx)
+	pendingContent, err := p.txSource.PendingContent(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch transaction pool content: %w", err)
+		return nil, fmt.Errorf("failed to fetch transaction pool content: %w", err)
 	}
 
 	log.Info("Fetching L2 pending transactions finished", "length", pendingContent.ToTxLists().Len())
 
+	// The transactions of the in-flight commits are still pending until their blocks are proposed.
+	inFlightNonces := p.inFlightNonces()
+
+	if isTick {
+		var (
+			pendingTxLists [][]*types.Transaction
+			gas            uint64
+		)
+		for _, txs := range excludeInFlightTxs(pendingContent, inFlightNonces).ToTxLists() {
+			pendingTxLists = append(pendingTxLists, txs)
+			gas += sumTxsGasLimit(txs)
+		}
+
+		if p.proposeTrigger.shouldSkipTick(gas) {
+			p.proposeTrigger.reset(pendingTxLists)
+			return nil, nil
+		}
+	}
+
+	l2Head, err := p.rpc.L2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L2 chain head: %w", err)
+	}
+
+	// The in-flight transactions are checked too, since the L2 chain head state doesn't include them.
+	if p.precheckTxLists {
+		if pendingContent, err = p.precheckPoolContent(ctx, pendingContent, l2Head); err != nil {
+			return nil, err
+		}
+	}
+	pendingContent = excludeInFlightTxs(pendingContent, inFlightNonces)
+
+	if p.poolContentSplitter.senderLists != nil {
+		if _, err := p.poolContentSplitter.senderLists.reload(); err != nil {
+			log.Error("Failed to reload sender lists, keep using the current ones", "error", err)
+		}
+	}
+
+	txLists, txListsBytes, err := p.txListPreValidator.splitValid(p.poolContentSplitter, pendingContent, l2Head.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	p.proposeTrigger.reset(txLists)
+
+	var remaining [][]*types.Transaction
+	if maxTxLists != 0 && len(txLists) > maxTxLists {
+		log.Info("Too many transactions lists, only commit a part of them", "txLists", len(txLists), "max", maxTxLists)
+		txLists, remaining = txLists[:maxTxLists], txLists[maxTxLists:]
+		txListsBytes = txListsBytes[:maxTxLists]
+	} else if isTick {
+		// The forced epochs neither defer the underfilled list nor count toward the deferred epochs.
+		var deferred []*types.Transaction
+		if txLists, txListsBytes, deferred = p.poolContentSplitter.deferUnderfilled(txLists, txListsBytes); deferred != nil {
+			remaining = [][]*types.Transaction{deferred}
+		}
+	}
+
 	var commitTxListResQueue []*commitTxListRes
-	for i, txs := range p.poolContentSplitter.split(pendingContent) {
-		txListBytes, err := rlp.EncodeToBytes(txs)
+
+	if p.profitabilityEstimator != nil && len(txLists) != 0 {
+		isProfitable, err := p.checkProfitability(ctx, txLists, txListsBytes, l2Head.BaseFee)
 		if err != nil {
-			return fmt.Errorf("failed to encode transactions: %w", err)
+			return nil, fmt.Errorf("failed to check proposing profitability: %w", err)
+		}
+
+		if !isProfitable {
+			return nil, nil
+		}
+	}
+
+	if p.dryRun {
+		return nil, p.dryRunOp(ctx, txLists, txListsBytes)
+	}
+
+	if len(txLists) != 0 {
+		p.feeRecipientSelector.startEpoch()
+	}
+
+	if p.commitDelayConfirmations > 0 && len(txLists) != 0 {
+		if err := p.commitSlotAllocator.sync(ctx, p.rpc); err != nil {
+			return nil, fmt.Errorf("failed to sync commit slots: %w", err)
 		}
+	}
+
+	senders := make(map[common.Hash]common.Address)
+	for sender, txs := range pendingContent {
+		for _, tx := range txs {
+			senders[tx.Hash()] = sender
+		}
+	}
 
-		meta, commitTx, err := p.CommitTxList(ctx, txListBytes, sumTxsGasLimit(txs), i)
+	for i, txs := range txLists {
+		txListBytes := txListsBytes[i]
+
+		meta, commitTx, err := p.CommitTxList(ctx, txListBytes, sumTxsGasLimit(txs))
 		if err != nil {
-			return fmt.Errorf("failed to commit transactions: %w", err)
+			p.proposeTrigger.reset(append(append([][]*types.Transaction{}, txLists[i:]...), remaining...))
+			return commitTxListResQueue, fmt.Errorf("failed to commit transactions: %w", err)
 		}
 
 		commitTxListResQueue = append(commitTxListResQueue, &commitTxListRes{
@@ -198,108 +668,193 @@ func (p *Proposer) ProposeOp(ctx context.Context) error {
 			commitTx:    commitTx,
 			txListBytes: txListBytes,
 			txNum:       uint(len(txs)),
+			nonces:      txListNonces(senders, txs),
 		})
 	}
 
+	p.proposeTrigger.reset(remaining)
+
 	if p.AfterCommitHook != nil {
 		if err := p.AfterCommitHook(); err != nil {
 			log.Error("Run AfterCommitHook error", "error", err)
 		}
 	}
 
-	for _, commitTxListRes := range commitTxListResQueue {
-		if err := p.ProposeTxList(ctx, commitTxListRes); err != nil {
-			return fmt.Errorf("failed to propose transactions: %w", err)
+	return commitTxListResQueue, nil
+}
+
+// checkProfitability checks whether the L2 tips collected by the given transactions lists can
+// cover the estimated L1 cost of committing and proposing them.
+func (p *Proposer) checkProfitability(
+	ctx context.Context,
+	txLists [][]*types.Transaction,
+	txListsBytes [][]byte,
+	l2BaseFee *big.Int,
+) (bool, error) {
+	l1GasPrice, err := p.estimateL1GasPrice(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	estimations := make([]*txListCostEstimation, len(txLists))
+	for i, txs := range txLists {
+		if estimations[i], err = p.profitabilityEstimator.estimateTxListCost(
+			txs, txListsBytes[i], l1GasPrice, l2BaseFee, p.commitDelayConfirmations > 0,
+		); err != nil {
+			return false, err
 		}
 	}
 
-	return nil
+	return p.profitabilityEstimator.isProfitable(estimations), nil
 }
 
-func (p *Proposer) CommitTxList(ctx context.Context, txListBytes []byte, gasLimit uint64, splittedIdx int) (
+// CommitTxList commits the given transactions list to TaikoL1 contract, using a free commit slot
+// allocated by the commitSlotAllocator, which will be reclaimed after the proposal.
+func (p *Proposer) CommitTxList(ctx context.Context, txListBytes []byte, gasLimit uint64) (
 	*bindings.LibDataBlockMetadata,
 	*types.Transaction,
 	error,
 ) {
 	// Assemble the block context and commit the txList
-	meta := &bindings.LibDataBlockMetadata{
-		Id:          common.Big0,
-		L1Height:    common.Big0,
-		L1Hash:      common.Hash{},
-		Beneficiary: p.l2SuggestedFeeRecipient,
-		GasLimit:    gasLimit,
-		TxListHash:  crypto.Keccak256Hash(txListBytes),
-		CommitSlot:  p.commitSlot + uint64(splittedIdx),
-	}
+	meta := p.newBlockMetadata(txListBytes, gasLimit, p.feeRecipientSelector.nextBeneficiary())
 
 	if p.commitDelayConfirmations == 0 {
 		log.Debug("No commit delay confirmation, skip committing transactions list")
 		return meta, nil, nil
 	}
 
-	opts, err := getTxOpts(ctx, p.rpc.L1, p.l1ProposerPrivKey, p.rpc.L1ChainID)
+	commitTx, err := p.commitBlockMetadata(ctx, meta, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	return meta, commitTx, nil
+}
+
+// commitBlockMetadata commits the transactions list of the given block metadata to TaikoL1 contract,
+// using a free commit slot allocated by the commitSlotAllocator, which is set to the metadata. If
+// `replaced` is not nil, the commit transaction is sent with its nonce.
+func (p *Proposer) commitBlockMetadata(
+	ctx context.Context,
+	meta *bindings.LibDataBlockMetadata,
+	replaced *types.Transaction,
+) (*types.Transaction, error) {
 	commitHash := common.BytesToHash(encoding.EncodeCommitHash(meta.Beneficiary, meta.TxListHash))
+	meta.CommitSlot = p.commitSlotAllocator.allocate(commitHash)
 
-	commitTx, err := p.rpc.TaikoL1.CommitBlock(opts, meta.CommitSlot, commitHash)
+	commitTx, err := p.sendTx(ctx, replaced, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.rpc.TaikoL1.CommitBlock(opts, meta.CommitSlot, commitHash)
+	})
 	if err != nil {
-		return nil, nil, err
+		p.commitSlotAllocator.release(meta.CommitSlot)
+		return nil, err
 	}
+	p.commitSlotAllocator.recordCommitTx(commitTx.Hash())
+	p.balanceWatchdog.onProposed()
 
-	return meta, commitTx, nil
+	return commitTx, nil
+}
+
+// newBlockMetadata assembles the context of a block to commit and propose, the commit slot
+// will be replaced by an allocated one when committing.
+func (p *Proposer) newBlockMetadata(
+	txListBytes []byte,
+	gasLimit uint64,
+	beneficiary common.Address,
+) *bindings.LibDataBlockMetadata {
+	return &bindings.LibDataBlockMetadata{
+		Id:          common.Big0,
+		L1Height:    common.Big0,
+		L1Hash:      common.Hash{},
+		Beneficiary: beneficiary,
+		GasLimit:    gasLimit,
+		TxListHash:  crypto.Keccak256Hash(txListBytes),
+		CommitSlot:  p.commitSlotAllocator.baseSlot,
+	}
 }
 
 func (p *Proposer) ProposeTxList(
 	ctx context.Context,
 	commitRes *commitTxListRes,
 ) error {
-	if p.commitDelayConfirmations > 0 {
-		receipt, err := rpc.WaitReceipt(ctx, p.rpc.L1, commitRes.commitTx)
-		if err != nil {
-			return err
-		}
+	proposeTx, receipt, err := p.proposeTxList(ctx, commitRes, nil)
+	if err != nil {
+		return err
+	}
 
-		if receipt.Status != types.ReceiptStatusSuccessful {
-			log.Error("Failed to commit transactions list", "txHash", receipt.TxHash)
-			return nil
-		}
+	if p.reorgConfirmations > 0 {
+		p.wg.Add(1)
+		go p.trackProposedTxList(commitRes, proposeTx, receipt)
+	}
 
-		log.Info(
-			"Commit block finished, wait some L1 blocks confirmations before proposing",
-			"commitHeight", receipt.BlockNumber,
-			"confirmations", p.commitDelayConfirmations,
-		)
+	return nil
+}
+
+// proposeTxList waits for the commit delay confirmations of the given committed transactions list,
+// and then proposes it, returns the propose transaction and its receipt. A reverted commit or
+// propose transaction is returned as an error. If `replaced` is not nil, the propose transaction
+// is sent with its nonce.
+func (p *Proposer) proposeTxList(
+	ctx context.Context,
+	commitRes *commitTxListRes,
+	replaced *types.Transaction,
+) (*types.Transaction, *types.Receipt, error) {
+	if p.commitDelayConfirmations > 0 {
+		// The commit slot might be changed by a re-commit.
+		defer func() { p.commitSlotAllocator.release(commitRes.meta.CommitSlot) }()
 
-		commitRes.meta.CommitHeight = receipt.BlockNumber.Uint64()
+		for {
+			receipt, err := p.txMgr.WaitReceipt(ctx, commitRes.commitTx)
+			if err != nil {
+				return nil, nil, err
+			}
+			// The mined one might be a re-broadcasted version.
+			p.commitSlotAllocator.recordCommitTx(receipt.TxHash)
+
+			log.Info(
+				"Commit block finished, wait some L1 blocks confirmations before proposing",
+				"commitHeight", receipt.BlockNumber,
+				"confirmations", p.commitDelayConfirmations,
+			)
+
+			commitHeight := receipt.BlockNumber
+			if receipt, err = p.waitFinalReceipt(ctx, receipt, p.commitDelayConfirmations); errors.Is(err, errReorgedOut) {
+				log.Warn("Commit transaction reorged out, commit the transactions list again", "error", err)
+				metrics.ProposerReorgedTxListsCounter.Inc(1)
+
+				oldSlot := commitRes.meta.CommitSlot
+				if err := p.recommitTxList(ctx, commitRes, nil); err != nil {
+					return nil, nil, err
+				}
+				p.commitSlotAllocator.release(oldSlot)
+				continue
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("wait L1 blocks confirmations error, commitHeight %s: %w", commitHeight, err)
+			}
 
-		if err := rpc.WaitConfirmations(
-			ctx, p.rpc.L1, p.commitDelayConfirmations, receipt.BlockNumber.Uint64(),
-		); err != nil {
-			return fmt.Errorf("wait L1 blocks confirmations error, commitHash %s: %w", receipt.BlockNumber, err)
+			commitRes.meta.CommitHeight = receipt.BlockNumber.Uint64()
+			break
 		}
 	}
 
 	// Propose the transactions list
 	inputs, err := encoding.EncodeProposeBlockInput(commitRes.meta, commitRes.txListBytes)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	opts, err := getTxOpts(ctx, p.rpc.L1, p.l1ProposerPrivKey, p.rpc.L1ChainID)
+	proposeTx, err := p.sendTx(ctx, replaced, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.rpc.TaikoL1.ProposeBlock(opts, inputs)
+	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	p.balanceWatchdog.onProposed()
 
-	proposeTx, err := p.rpc.TaikoL1.ProposeBlock(opts, inputs)
+	receipt, err := p.txMgr.WaitReceipt(ctx, proposeTx)
 	if err != nil {
-		return err
-	}
-
-	if _, err := rpc.WaitReceipt(ctx, p.rpc.L1, proposeTx); err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	log.Info("📝 Propose transactions succeeded")
@@ -307,7 +862,21 @@ func (p *Proposer) ProposeTxList(
 	metrics.ProposerProposedTxListsCounter.Inc(1)
 	metrics.ProposerProposedTxsCounter.Inc(int64(commitRes.txNum))
 
-	return nil
+	return proposeTx, receipt, nil
+}
+
+// sendTx sends the transaction created by `build` through the transaction manager, with the nonce
+// of `replaced` if it's not nil.
+func (p *Proposer) sendTx(
+	ctx context.Context,
+	replaced *types.Transaction,
+	build func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*types.Transaction, error) {
+	if replaced != nil {
+		return p.txMgr.Replace(ctx, replaced, build)
+	}
+
+	return p.txMgr.Send(ctx, build)
 }
 
 // Name returns the application name.
@@ -324,28 +893,43 @@ func sumTxsGasLimit(txs []*types.Transaction) uint64 {
 	return total
 }
 
-// getTxOpts creates a bind.TransactOpts instance using the given private key.
-func getTxOpts(
-	ctx context.Context,
-	cli *ethclient.Client,
-	privKey *ecdsa.PrivateKey,
-	chainID *big.Int,
-) (*bind.TransactOpts, error) {
-	opts, err := bind.NewKeyedTransactorWithChainID(privKey, chainID)
+// encodeTxList encodes the given transactions list, and then compresses it with the given codec,
+// the compressed bytes will only be used when they are smaller than the uncompressed ones.
+func encodeTxList(txs []*types.Transaction, codec byte) ([]byte, error) {
+	txListBytes, err := rlp.EncodeToBytes(txs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate prepareBlock transaction options: %w", err)
+		return nil, err
+	}
+
+	if codec == encoding.TxListCodecNone {
+		return txListBytes, nil
 	}
 
-	gasTipCap, err := cli.SuggestGasTipCap(ctx)
+	compressed, err := encoding.CompressTxListBytes(txListBytes, codec)
 	if err != nil {
-		if rpc.IsMaxPriorityFeePerGasNotFoundError(err) {
-			gasTipCap = rpc.FallbackGasTipCap
-		} else {
-			return nil, err
-		}
+		return nil, err
+	}
+
+	if len(compressed) >= len(txListBytes) {
+		return txListBytes, nil
 	}
 
-	opts.GasTipCap = gasTipCap
+	return compressed, nil
+}
+
+// getTxOpts creates a bind.TransactOpts instance using the given signer, and the fees
+// suggested by the given fee strategy.
+func getTxOpts(
+	ctx context.Context,
+	fees *feeStrategy.FeeStrategy,
+	s signer.Signer,
+	chainID *big.Int,
+) (*bind.TransactOpts, error) {
+	opts := signer.NewTransactOpts(ctx, s, chainID)
+
+	if err := fees.Apply(ctx, opts); err != nil {
+		return nil, err
+	}
 
 	return opts, nil
 }
@@ -9,9 +9,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/suite"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
 
@@ -29,13 +31,15 @@ func (s *ProposerTestSuite) SetupTest() {
 	p := new(Proposer)
 
 	s.Nil(InitFromConfig(context.Background(), p, (&Config{
-		L1Endpoint:              os.Getenv("L1_NODE_ENDPOINT"),
-		L2Endpoint:              os.Getenv("L2_NODE_ENDPOINT"),
-		TaikoL1Address:          common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
-		TaikoL2Address:          common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
-		L1ProposerPrivKey:       l1ProposerPrivKey,
-		L2SuggestedFeeRecipient: common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")),
-		ProposeInterval:         1024 * time.Hour, // No need to periodically propose transactions list in unit tests
+		L1Endpoint:       os.Getenv("L1_NODE_ENDPOINT"),
+		L2Endpoint:       os.Getenv("L2_NODE_ENDPOINT"),
+		TaikoL1Address:   common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		TaikoL2Address:   common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		L1ProposerSigner: signer.NewPrivateKeySigner(l1ProposerPrivKey),
+		L2SuggestedFeeRecipients: []*FeeRecipient{
+			{Address: common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")), Weight: 1},
+		},
+		ProposeInterval: 1024 * time.Hour, // No need to periodically propose transactions list in unit tests
 	})))
 
 	s.p = p
@@ -85,14 +89,17 @@ func (s *ProposerTestSuite) TestProposeOp() {
 	s.Nil(err)
 	s.Nil(s.p.rpc.L2.SendTransaction(context.Background(), signedTx))
 
+	// A forced epoch doesn't defer the underfilled transactions list.
+	s.p.poolContentSplitter.minFillPercentage = 100
 	s.Nil(s.p.ProposeOp(context.Background()))
+	s.Zero(s.p.poolContentSplitter.deferredEpochs)
 
 	event := <-sink
 
 	_, isPending, err := s.p.rpc.L1.TransactionByHash(context.Background(), event.Raw.TxHash)
 	s.Nil(err)
 	s.False(isPending)
-	s.Equal(s.p.l2SuggestedFeeRecipient, event.Meta.Beneficiary)
+	s.Equal(common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")), event.Meta.Beneficiary)
 
 	receipt, err := s.p.rpc.L1.TransactionReceipt(context.Background(), event.Raw.TxHash)
 	s.Nil(err)
@@ -103,7 +110,7 @@ func (s *ProposerTestSuite) TestCommitTxList() {
 	txListBytes := testutils.RandomBytes(1024)
 	gasLimit := uint64(102400)
 
-	meta, tx, err := s.p.CommitTxList(context.Background(), txListBytes, gasLimit, 0)
+	meta, tx, err := s.p.CommitTxList(context.Background(), txListBytes, gasLimit)
 	s.Nil(err)
 	s.Equal(meta.GasLimit, gasLimit)
 
@@ -112,6 +119,102 @@ func (s *ProposerTestSuite) TestCommitTxList() {
 	s.Equal(types.ReceiptStatusSuccessful, receipt.Status)
 }
 
+func (s *ProposerTestSuite) TestCommitAndEnqueueOpTooManyInFlightCommits() {
+	p := &Proposer{
+		maxPendingBlocks:     2,
+		inFlightCommits:      []*commitTxListRes{{}, {}},
+		commitTxListResQueue: make(chan *commitTxListRes, 2),
+	}
+
+	// Skip committing without fetching the L2 pending transactions.
+	s.Nil(p.commitAndEnqueueOp(context.Background(), true))
+	s.Zero(len(p.commitTxListResQueue))
+}
+
+func (s *ProposerTestSuite) TestCommitAndEnqueueOpExcludesInFlightTxs() {
+	nonce, err := s.p.rpc.L2.PendingNonceAt(context.Background(), s.TestAddr)
+	s.Nil(err)
+
+	for i := uint64(0); i < 2; i++ {
+		tx := types.NewTransaction(
+			nonce+i,
+			common.BytesToAddress(testutils.RandomBytes(32)),
+			common.Big1,
+			100000,
+			common.Big1,
+			[]byte{},
+		)
+		signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(s.p.rpc.L2ChainID), s.TestAddrPrivKey)
+		s.Nil(err)
+		s.Nil(s.p.rpc.L2.SendTransaction(context.Background(), signedTx))
+	}
+
+	// Two epochs back to back against the same pool, the first epoch's lists are still in flight.
+	s.Nil(s.p.commitAndEnqueueOp(context.Background(), false))
+	s.Nil(s.p.commitAndEnqueueOp(context.Background(), false))
+	defer func() {
+		for _, commitRes := range s.p.getInFlightCommits() {
+			s.p.removeInFlightCommit(commitRes)
+		}
+	}()
+
+	committed := make(map[common.Hash]struct{})
+	for len(s.p.commitTxListResQueue) != 0 {
+		var txs types.Transactions
+		s.Nil(rlp.DecodeBytes((<-s.p.commitTxListResQueue).txListBytes, &txs))
+
+		for _, tx := range txs {
+			s.NotContains(committed, tx.Hash())
+			committed[tx.Hash()] = struct{}{}
+		}
+	}
+	s.NotEmpty(committed)
+}
+
+func (s *ProposerTestSuite) TestExcludeInFlightTxs() {
+	var (
+		sender      = common.HexToAddress("0x1")
+		otherSender = common.HexToAddress("0x2")
+		pool        = rpc.PoolContent{
+			sender: {
+				"0": newDynamicFeeTx(0, 1, 10),
+				"1": newDynamicFeeTx(1, 1, 10),
+			},
+			otherSender: {"0": newDynamicFeeTx(0, 2, 10)},
+		}
+		senders = make(map[common.Hash]common.Address)
+		p       = new(Proposer)
+	)
+	for sender, txs := range pool {
+		for _, tx := range txs {
+			senders[tx.Hash()] = sender
+		}
+	}
+
+	// The first epoch commits the sender's transactions.
+	s.Equal(pool, excludeInFlightTxs(pool, p.inFlightNonces()))
+	commitRes := &commitTxListRes{
+		nonces: txListNonces(senders, types.Transactions{pool[sender]["0"], pool[sender]["1"]}),
+	}
+	s.Equal(map[common.Address]uint64{sender: 1}, commitRes.nonces)
+	p.addInFlightCommit(commitRes)
+
+	// The second epoch against the same pool, the committed transactions and their replacements
+	// are excluded, while the sender's next transaction is kept.
+	pool[sender]["1"] = newDynamicFeeTx(1, 5, 10)
+	pool[sender]["2"] = newDynamicFeeTx(2, 1, 10)
+
+	excluded := excludeInFlightTxs(pool, p.inFlightNonces())
+	s.Equal(rpc.PoolContent{
+		sender:      {"2": pool[sender]["2"]},
+		otherSender: pool[otherSender],
+	}, excluded)
+
+	// The transactions are proposed again if the in-flight commit fails.
+	p.removeInFlightCommit(commitRes)
+	s.Equal(pool, excludeInFlightTxs(pool, p.inFlightNonces()))
+}
+
 func TestProposerTestSuite(t *testing.T) {
 	suite.Run(t, new(ProposerTestSuite))
 }
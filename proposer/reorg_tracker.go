@@ -0,0 +1,169 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	txManager "github.com/taikoxyz/taiko-client/pkg/tx_manager"
+)
+
+var (
+	// errReorgedOut is returned when a transaction is reorged out of the canonical L1 chain,
+	// and not included again in time, or reverted after being included again.
+	errReorgedOut = errors.New("transaction reorged out of the canonical chain")
+
+	// Time to wait for a reorged out transaction to be included again, before giving up.
+	reorgReinclusionTimeout = 2 * time.Minute
+	// Interval between the receipt checks while waiting for the confirmations.
+	reorgCheckInterval = time.Second
+)
+
+// waitFinalReceipt waits until the given receipt has the given L1 blocks confirmations, while
+// re-checking that its transaction is still included in the canonical chain. If the transaction
+// is reorged out and included again, the new receipt will be waited for instead.
+func (p *Proposer) waitFinalReceipt(
+	ctx context.Context,
+	receipt *types.Receipt,
+	confirmations uint64,
+) (*types.Receipt, error) {
+	ticker := time.NewTicker(reorgCheckInterval)
+	defer ticker.Stop()
+
+	var reorgedAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := p.rpc.L1.TransactionReceipt(ctx, receipt.TxHash)
+		if err != nil {
+			if !errors.Is(err, ethereum.NotFound) {
+				log.Warn("Failed to fetch transaction receipt", "hash", receipt.TxHash, "error", err)
+				continue
+			}
+
+			if reorgedAt.IsZero() {
+				log.Warn(
+					"Transaction reorged out, wait for it to be included again",
+					"hash", receipt.TxHash,
+					"height", receipt.BlockNumber,
+					"blockHash", receipt.BlockHash,
+				)
+				reorgedAt = time.Now()
+			}
+
+			if time.Since(reorgedAt) > reorgReinclusionTimeout {
+				return nil, fmt.Errorf("%w, hash: %s", errReorgedOut, receipt.TxHash)
+			}
+			continue
+		}
+		reorgedAt = time.Time{}
+
+		if current.BlockHash != receipt.BlockHash {
+			log.Info(
+				"Transaction included again",
+				"hash", current.TxHash,
+				"height", current.BlockNumber,
+				"blockHash", current.BlockHash,
+			)
+
+			if current.Status != types.ReceiptStatusSuccessful {
+				return nil, fmt.Errorf("%w, reverted after being included again, hash: %s", errReorgedOut, current.TxHash)
+			}
+			receipt = current
+		}
+
+		latest, err := p.rpc.L1.BlockNumber(ctx)
+		if err != nil {
+			log.Warn("Failed to fetch latest L1 block number", "error", err)
+			continue
+		}
+
+		if latest >= receipt.BlockNumber.Uint64()+confirmations {
+			return receipt, nil
+		}
+	}
+}
+
+// recommitTxList commits the given transactions list again with its original block metadata, only
+// the commit slot and height are changed, after its previous commit transaction or its propose
+// transaction has been reorged out. If `replaced` is not nil, the commit transaction is sent with
+// its nonce.
+func (p *Proposer) recommitTxList(
+	ctx context.Context,
+	commitRes *commitTxListRes,
+	replaced *types.Transaction,
+) error {
+	meta := *commitRes.meta
+	meta.CommitHeight = 0
+
+	commitTx, err := p.commitBlockMetadata(ctx, &meta, replaced)
+	if err != nil {
+		return fmt.Errorf("failed to commit transactions list again: %w", err)
+	}
+
+	// Also read by the admin RPC server.
+	p.inFlightCommitsMu.Lock()
+	defer p.inFlightCommitsMu.Unlock()
+
+	commitRes.meta, commitRes.commitTx = &meta, commitTx
+
+	return nil
+}
+
+// trackProposedTxList tracks the given propose transaction receipt until it's final, and proposes
+// the transactions list again, after committing it again if needed, whenever the propose transaction
+// is reorged out. The reorged out propose transaction might still be included again later, so the
+// first transaction sent again takes its nonce, to make sure the transactions list is proposed at
+// most once.
+func (p *Proposer) trackProposedTxList(
+	commitRes *commitTxListRes,
+	proposeTx *types.Transaction,
+	receipt *types.Receipt,
+) {
+	defer p.wg.Done()
+
+	for {
+		_, err := p.waitFinalReceipt(p.ctx, receipt, p.reorgConfirmations)
+		if !errors.Is(err, errReorgedOut) {
+			return
+		}
+
+		log.Warn("Propose transaction reorged out, propose the transactions list again", "error", err)
+		metrics.ProposerReorgedTxListsCounter.Inc(1)
+
+		replaced := proposeTx
+		if p.commitDelayConfirmations > 0 {
+			if err := p.recommitTxList(p.ctx, commitRes, replaced); err != nil {
+				logReproposeError("Failed to re-commit reorged out transactions list", err)
+				return
+			}
+			replaced = nil
+		}
+
+		if proposeTx, receipt, err = p.proposeTxList(p.ctx, commitRes, replaced); err != nil {
+			logReproposeError("Failed to re-propose reorged out transactions list", err)
+			return
+		}
+	}
+}
+
+// logReproposeError logs the error of proposing a reorged out transactions list again.
+func logReproposeError(msg string, err error) {
+	if errors.Is(err, txManager.ErrNonceUsed) {
+		// The transactions are still in the L2 node's pool if not proposed, so they will be proposed
+		// again in the next epochs.
+		log.Warn("Reorged out propose transaction's nonce has been used again, skip proposing it again", "error", err)
+		return
+	}
+
+	log.Error(msg, "error", err)
+}
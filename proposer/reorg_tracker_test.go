@@ -0,0 +1,109 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+// mockReceiptAPI is a mock L1 node serving the transaction receipt and block number queries.
+type mockReceiptAPI struct {
+	mu      sync.Mutex
+	receipt *types.Receipt // nil if the transaction is not in the canonical chain
+	head    uint64
+}
+
+// GetTransactionReceipt returns the receipt of the tracked transaction.
+func (api *mockReceiptAPI) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	return api.receipt, nil
+}
+
+// BlockNumber returns the latest block number.
+func (api *mockReceiptAPI) BlockNumber() hexutil.Uint64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	return hexutil.Uint64(api.head)
+}
+
+// setReceipt changes the receipt of the tracked transaction.
+func (api *mockReceiptAPI) setReceipt(receipt *types.Receipt) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.receipt = receipt
+}
+
+func (s *ProposerTestSuite) TestWaitFinalReceipt() {
+	defer func(interval, timeout time.Duration) {
+		reorgCheckInterval, reorgReinclusionTimeout = interval, timeout
+	}(reorgCheckInterval, reorgReinclusionTimeout)
+	reorgCheckInterval, reorgReinclusionTimeout = 10*time.Millisecond, 200*time.Millisecond
+
+	newReceipt := func(height int64, status uint64) *types.Receipt {
+		return &types.Receipt{
+			Status:      status,
+			Logs:        []*types.Log{},
+			TxHash:      common.BytesToHash([]byte{0x01}),
+			BlockHash:   common.BytesToHash(testutils.RandomBytes(32)),
+			BlockNumber: big.NewInt(height),
+		}
+	}
+
+	var (
+		receipt = newReceipt(10, types.ReceiptStatusSuccessful)
+		api     = &mockReceiptAPI{receipt: receipt, head: 12}
+		srv     = gethRPC.NewServer()
+	)
+	s.Nil(srv.RegisterName("eth", api))
+
+	httpSrv := httptest.NewServer(srv)
+	defer func() {
+		httpSrv.Close()
+		srv.Stop()
+	}()
+
+	client, err := gethRPC.Dial(httpSrv.URL)
+	s.Nil(err)
+
+	p := &Proposer{rpc: &rpc.Client{L1: ethclient.NewClient(client)}}
+
+	// Canonical and confirmed.
+	final, err := p.waitFinalReceipt(context.Background(), receipt, 2)
+	s.Nil(err)
+	s.Equal(receipt.BlockHash, final.BlockHash)
+
+	// Reorged out and then included again.
+	api.setReceipt(nil)
+	reincluded := newReceipt(11, types.ReceiptStatusSuccessful)
+	time.AfterFunc(50*time.Millisecond, func() { api.setReceipt(reincluded) })
+
+	final, err = p.waitFinalReceipt(context.Background(), receipt, 1)
+	s.Nil(err)
+	s.Equal(reincluded.BlockHash, final.BlockHash)
+
+	// Reverted after being included again.
+	api.setReceipt(newReceipt(11, types.ReceiptStatusFailed))
+
+	_, err = p.waitFinalReceipt(context.Background(), receipt, 1)
+	s.ErrorIs(err, errReorgedOut)
+
+	// Not included again in time.
+	api.setReceipt(nil)
+
+	_, err = p.waitFinalReceipt(context.Background(), receipt, 1)
+	s.ErrorIs(err, errReorgedOut)
+}
@@ -0,0 +1,173 @@
+package proposer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// senderListsFile is the JSON format of the sender lists file.
+type senderListsFile struct {
+	PriorityAccounts  []common.Address `json:"priorityAccounts"`
+	PriorityContracts []common.Address `json:"priorityContracts"`
+	DeniedSenders     []common.Address `json:"deniedSenders"`
+}
+
+// senderLists contains the L2 accounts which should be treated specially by the proposer:
+// pending transactions sent by the priority accounts or calling the priority contracts are
+// always proposed before the general pool, and transactions sent by the denied senders are
+// never proposed. The lists are loaded from a JSON file, which will be reloaded once modified.
+type senderLists struct {
+	path string
+
+	mu                sync.RWMutex
+	modTime           time.Time
+	priorityAccounts  map[common.Address]struct{}
+	priorityContracts map[common.Address]struct{}
+	deniedSenders     map[common.Address]struct{}
+}
+
+// newSenderLists creates a new senderLists instance, and loads the lists from the given file.
+func newSenderLists(path string) (*senderLists, error) {
+	l := &senderLists{path: path}
+	if _, err := l.reload(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// reload reloads the lists if the file has been modified since the last loading, if the
+// new file content is invalid, the current lists will be kept.
+func (l *senderLists) reload() (bool, error) {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat sender lists file: %w", err)
+	}
+
+	l.mu.RLock()
+	modTime := l.modTime
+	l.mu.RUnlock()
+
+	if info.ModTime().Equal(modTime) {
+		return false, nil
+	}
+
+	b, err := os.ReadFile(l.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read sender lists file: %w", err)
+	}
+
+	var file senderListsFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return false, fmt.Errorf("failed to decode sender lists file: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.modTime = info.ModTime()
+	l.priorityAccounts = toAddressSet(file.PriorityAccounts)
+	l.priorityContracts = toAddressSet(file.PriorityContracts)
+	l.deniedSenders = toAddressSet(file.DeniedSenders)
+
+	log.Info(
+		"Sender lists loaded",
+		"priorityAccounts", len(l.priorityAccounts),
+		"priorityContracts", len(l.priorityContracts),
+		"deniedSenders", len(l.deniedSenders),
+	)
+
+	return true, nil
+}
+
+// partition removes the denied senders' transactions from the given pool content, and then
+// partitions the remaining transactions into the priority lane and the general pool. For each
+// sender, the transactions up to the last one calling a priority contract are in the priority
+// lane, so that the nonce order between two lanes is kept.
+func (l *senderLists) partition(poolContent rpc.PoolContent) (priority rpc.TxLists, general rpc.TxLists) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for sender, txList := range poolContent.ToSenderTxLists() {
+		if _, ok := l.deniedSenders[sender]; ok {
+			log.Debug("Ignore transactions from denied sender", "sender", sender, "length", len(txList))
+			continue
+		}
+
+		if _, ok := l.priorityAccounts[sender]; ok {
+			priority = append(priority, txList)
+			continue
+		}
+
+		prioritized := 0
+		for i, tx := range txList {
+			if tx.To() == nil {
+				continue
+			}
+			if _, ok := l.priorityContracts[*tx.To()]; ok {
+				prioritized = i + 1
+			}
+		}
+
+		if prioritized > 0 {
+			priority = append(priority, txList[:prioritized])
+		}
+		if prioritized < len(txList) {
+			general = append(general, txList[prioritized:])
+		}
+	}
+
+	return priority, general
+}
+
+// toAddressSet converts the given addresses into a set.
+func toAddressSet(addresses []common.Address) map[common.Address]struct{} {
+	set := make(map[common.Address]struct{}, len(addresses))
+	for _, address := range addresses {
+		set[address] = struct{}{}
+	}
+	return set
+}
+
+// chainedTxIterator iterates the given TxIterators one by one.
+type chainedTxIterator struct {
+	iters []TxIterator
+}
+
+// newChainedTxIterator creates a new chainedTxIterator instance.
+func newChainedTxIterator(iters ...TxIterator) *chainedTxIterator {
+	return &chainedTxIterator{iters: iters}
+}
+
+// Peek implements the TxIterator interface.
+func (it *chainedTxIterator) Peek() *types.Transaction {
+	for len(it.iters) > 0 {
+		if tx := it.iters[0].Peek(); tx != nil {
+			return tx
+		}
+		it.iters = it.iters[1:]
+	}
+	return nil
+}
+
+// Shift implements the TxIterator interface.
+func (it *chainedTxIterator) Shift() {
+	if it.Peek() != nil {
+		it.iters[0].Shift()
+	}
+}
+
+// Pop implements the TxIterator interface.
+func (it *chainedTxIterator) Pop() {
+	if it.Peek() != nil {
+		it.iters[0].Pop()
+	}
+}
@@ -0,0 +1,154 @@
+package proposer
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+func (s *ProposerTestSuite) TestSenderListsReload() {
+	var (
+		path    = filepath.Join(s.T().TempDir(), "senderLists.json")
+		account = common.BytesToAddress(testutils.RandomBytes(20))
+	)
+
+	_, err := newSenderLists(path)
+	s.NotNil(err)
+
+	s.Nil(os.WriteFile(path, []byte(`{"priorityAccounts":["`+account.Hex()+`"]}`), 0600))
+
+	lists, err := newSenderLists(path)
+	s.Nil(err)
+	s.Contains(lists.priorityAccounts, account)
+	s.Empty(lists.deniedSenders)
+
+	// Not modified.
+	reloaded, err := lists.reload()
+	s.Nil(err)
+	s.False(reloaded)
+
+	// Invalid content, keep using the current lists.
+	s.Nil(os.WriteFile(path, []byte(`{"deniedSenders":`), 0600))
+	s.Nil(os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	_, err = lists.reload()
+	s.NotNil(err)
+	s.Contains(lists.priorityAccounts, account)
+
+	s.Nil(os.WriteFile(path, []byte(`{"deniedSenders":["`+account.Hex()+`"]}`), 0600))
+	s.Nil(os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+	reloaded, err = lists.reload()
+	s.Nil(err)
+	s.True(reloaded)
+	s.Empty(lists.priorityAccounts)
+	s.Contains(lists.deniedSenders, account)
+}
+
+func (s *ProposerTestSuite) TestSplitWithSenderLists() {
+	var (
+		priorityAccount  = common.BytesToAddress(testutils.RandomBytes(20))
+		priorityContract = common.BytesToAddress(testutils.RandomBytes(20))
+		deniedSender     = common.BytesToAddress(testutils.RandomBytes(20))
+		generalSender    = common.BytesToAddress(testutils.RandomBytes(20))
+		txGeneral0       = newDynamicFeeTx(0, 100, 100)
+		txGeneral1       = newTxTo(1, priorityContract)
+		txGeneral2       = newDynamicFeeTx(2, 100, 100)
+		txPriority0      = newDynamicFeeTx(0, 1, 100)
+		txDenied0        = newDynamicFeeTx(0, 100, 100)
+	)
+
+	strategy, err := NewTxOrderingStrategy(TxOrderingMaxTip)
+	s.Nil(err)
+
+	splitter := &poolContentSplitter{
+		txOrderingStrategy: strategy,
+		senderLists: &senderLists{
+			priorityAccounts:  toAddressSet([]common.Address{priorityAccount}),
+			priorityContracts: toAddressSet([]common.Address{priorityContract}),
+			deniedSenders:     toAddressSet([]common.Address{deniedSender}),
+		},
+		maxTxPerBlock:      10,
+		maxGasPerBlock:     10 * txGeneral0.Gas(),
+		maxTxBytesPerBlock: 10240,
+		minTxGasLimit:      txGeneral0.Gas(),
+	}
+
+	poolContent := rpc.PoolContent{
+		priorityAccount: {"0": txPriority0},
+		generalSender:   {"0": txGeneral0, "1": txGeneral1, "2": txGeneral2},
+		deniedSender:    {"0": txDenied0},
+	}
+
+	splitted := splitter.split(poolContent, common.Big0)
+	s.Equal(1, len(splitted))
+	s.Equal(4, len(splitted[0]))
+
+	// The general sender's transactions up to the one calling the priority contract
+	// are in the priority lane, the priority account's transaction has the lowest tip.
+	s.Equal(txGeneral0.Hash(), splitted[0][0].Hash())
+	s.Equal(txGeneral1.Hash(), splitted[0][1].Hash())
+	s.Equal(txPriority0.Hash(), splitted[0][2].Hash())
+	s.Equal(txGeneral2.Hash(), splitted[0][3].Hash())
+
+	// If the priority part of a sender is invalid, its general part is ignored too.
+	splitter.maxGasPerBlock = txGeneral0.Gas()
+	splitter.minTxGasLimit = txGeneral0.Gas()
+	poolContent[generalSender]["1"] = types.NewTx(&types.DynamicFeeTx{
+		Nonce: 1, GasTipCap: common.Big1, GasFeeCap: common.Big1, Gas: 1, To: &priorityContract,
+	})
+
+	splitted = splitter.split(poolContent, common.Big0)
+	s.Equal(2, len(splitted))
+	s.Equal(txGeneral0.Hash(), splitted[0][0].Hash())
+	s.Equal(txPriority0.Hash(), splitted[1][0].Hash())
+}
+
+func (s *ProposerTestSuite) TestSplitWithSenderListsFirstSeen() {
+	var (
+		priorityAccount = common.BytesToAddress(testutils.RandomBytes(20))
+		generalSender   = common.BytesToAddress(testutils.RandomBytes(20))
+		txPriority0     = newDynamicFeeTx(0, 1, 100)
+		txGeneral0      = newDynamicFeeTx(0, 2, 100)
+		general         = newFirstSeenOrdering()
+		priority        = newFirstSeenOrdering()
+	)
+
+	splitter := &poolContentSplitter{
+		txOrderingStrategy: general,
+		priorityOrdering:   priority,
+		senderLists:        &senderLists{priorityAccounts: toAddressSet([]common.Address{priorityAccount})},
+		maxTxPerBlock:      10,
+		maxGasPerBlock:     10 * txGeneral0.Gas(),
+		maxTxBytesPerBlock: 10240,
+		minTxGasLimit:      txGeneral0.Gas(),
+	}
+
+	splitted := splitter.split(rpc.PoolContent{
+		priorityAccount: {"0": txPriority0},
+		generalSender:   {"0": txGeneral0},
+	}, common.Big0)
+	s.Equal(1, len(splitted))
+	s.Equal(2, len(splitted[0]))
+
+	// Each lane's first seen records are kept by its own strategy instance.
+	s.Len(priority.firstSeen, 1)
+	s.Contains(priority.firstSeen, txPriority0.Hash())
+	s.Len(general.firstSeen, 1)
+	s.Contains(general.firstSeen, txGeneral0.Hash())
+}
+
+// newTxTo creates a new unsigned dynamic fee transaction calling the given address for testing.
+func newTxTo(nonce uint64, to common.Address) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: common.Big2,
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &to,
+	})
+}
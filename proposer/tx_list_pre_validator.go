@@ -0,0 +1,135 @@
+package proposer
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+)
+
+// txListPreValidator runs the split transactions lists through the same TxListValidator used by
+// drivers and provers before committing them, so that an invalid transaction won't turn a whole
+// proposed block into a throwaway block.
+type txListPreValidator struct {
+	validator  *txListValidator.TxListValidator
+	invalidTxs map[common.Hash]txListValidator.InvalidTxListReason // Dropped transactions still in the pool
+}
+
+// newTxListPreValidator creates a new txListPreValidator instance.
+func newTxListPreValidator(validator *txListValidator.TxListValidator) *txListPreValidator {
+	return &txListPreValidator{
+		validator:  validator,
+		invalidTxs: make(map[common.Hash]txListValidator.InvalidTxListReason),
+	}
+}
+
+// splitValid splits the given pool content with the given splitter, and then validates the encoded
+// transactions lists. The invalid transactions are dropped together with the same sender's
+// transactions with larger nonces, and the remaining pool content is split again, until all
+// transactions lists are valid.
+func (v *txListPreValidator) splitValid(
+	splitter *poolContentSplitter,
+	poolContent rpc.PoolContent,
+	baseFee *big.Int,
+) ([][]*types.Transaction, [][]byte, error) {
+	v.prune(poolContent)
+
+	// Every round drops at least one transaction which is still in the pool content,
+	// so the loop always ends.
+	for {
+		var (
+			txLists      = splitter.split(v.filter(poolContent), baseFee)
+			txListsBytes = make([][]byte, len(txLists))
+			dropped      bool
+		)
+
+		for i, txs := range txLists {
+			txListBytes, err := encodeTxList(txs, splitter.txListCodec)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to encode transactions: %w", err)
+			}
+			txListsBytes[i] = txListBytes
+
+			hint, txIdx := v.validator.IsTxListValid(nil, txListBytes)
+			switch hint {
+			case txListValidator.HintOK:
+				continue
+			case txListValidator.HintTxInvalidSig, txListValidator.HintTxGasLimitTooSmall:
+				v.drop(txs[txIdx], hint)
+			default:
+				// The splitter should have already kept the transactions lists within the
+				// protocol limits, so drop the whole list to be safe.
+				log.Error("Invalid split transactions list", "length", len(txs), "reason", hint)
+				for _, tx := range txs {
+					v.drop(tx, hint)
+				}
+			}
+			dropped = true
+		}
+
+		if !dropped {
+			return txLists, txListsBytes, nil
+		}
+	}
+}
+
+// drop marks the given transaction as invalid.
+func (v *txListPreValidator) drop(tx *types.Transaction, reason txListValidator.InvalidTxListReason) {
+	log.Warn("Drop invalid pending transaction", "hash", tx.Hash(), "nonce", tx.Nonce(), "reason", reason)
+	metrics.ProposerDroppedTxsCounter.Inc(1)
+
+	v.invalidTxs[tx.Hash()] = reason
+}
+
+// filter returns a copy of the given pool content without the invalid transactions, and the same
+// sender's transactions with larger nonces, which can't be executed anymore.
+func (v *txListPreValidator) filter(poolContent rpc.PoolContent) rpc.PoolContent {
+	if len(v.invalidTxs) == 0 {
+		return poolContent
+	}
+
+	filtered := make(rpc.PoolContent, len(poolContent))
+	for sender, txs := range poolContent {
+		sorted := make(types.Transactions, 0, len(txs))
+		for _, tx := range txs {
+			sorted = append(sorted, tx)
+		}
+		sort.Sort(types.TxByNonce(sorted))
+
+		senderTxs := make(map[string]*types.Transaction)
+		for _, tx := range sorted {
+			if _, ok := v.invalidTxs[tx.Hash()]; ok {
+				break
+			}
+			senderTxs[fmt.Sprint(tx.Nonce())] = tx
+		}
+
+		if len(senderTxs) != 0 {
+			filtered[sender] = senderTxs
+		}
+	}
+
+	return filtered
+}
+
+// prune forgets the invalid transactions which are not in the given pool content anymore.
+func (v *txListPreValidator) prune(poolContent rpc.PoolContent) {
+	pending := make(map[common.Hash]struct{})
+	for _, txs := range poolContent {
+		for _, tx := range txs {
+			pending[tx.Hash()] = struct{}{}
+		}
+	}
+
+	for hash := range v.invalidTxs {
+		if _, ok := pending[hash]; !ok {
+			delete(v.invalidTxs, hash)
+		}
+	}
+}
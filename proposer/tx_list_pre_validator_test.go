@@ -0,0 +1,76 @@
+package proposer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+)
+
+func (s *ProposerTestSuite) TestTxListPreValidator() {
+	var (
+		chainID      = big.NewInt(167)
+		wrongChainID = big.NewInt(1)
+		splitter     = &poolContentSplitter{
+			maxTxPerBlock:      10,
+			maxGasPerBlock:     1_000_000,
+			maxTxBytesPerBlock: 10_000,
+			minTxGasLimit:      21000,
+		}
+		preValidator = newTxListPreValidator(txListValidator.NewTxListValidator(
+			splitter.maxGasPerBlock,
+			splitter.maxTxPerBlock,
+			splitter.maxTxBytesPerBlock,
+			splitter.minTxGasLimit,
+			chainID,
+			false,
+		))
+	)
+
+	signTx := func(nonce uint64, chainID *big.Int) (*types.Transaction, common.Address) {
+		privKey, err := crypto.GenerateKey()
+		s.Nil(err)
+
+		tx, err := types.SignTx(
+			types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, Gas: 21000, GasFeeCap: common.Big1}),
+			types.LatestSignerForChainID(chainID),
+			privKey,
+		)
+		s.Nil(err)
+
+		return tx, crypto.PubkeyToAddress(privKey.PublicKey)
+	}
+
+	validTx, validSender := signTx(0, chainID)
+	invalidTx, invalidSender := signTx(0, wrongChainID)
+	nextTx, _ := signTx(1, chainID)
+
+	poolContent := rpc.PoolContent{
+		validSender:   {"0": validTx},
+		invalidSender: {"0": invalidTx, "1": nextTx},
+	}
+
+	// The invalid transaction is dropped together with the same sender's next transaction.
+	txLists, txListsBytes, err := preValidator.splitValid(splitter, poolContent, nil)
+	s.Nil(err)
+	s.Equal([][]*types.Transaction{{validTx}}, txLists)
+	s.Len(txListsBytes, 1)
+	s.Equal(
+		map[common.Hash]txListValidator.InvalidTxListReason{invalidTx.Hash(): txListValidator.HintTxInvalidSig},
+		preValidator.invalidTxs,
+	)
+
+	hint, _ := preValidator.validator.IsTxListValid(nil, txListsBytes[0])
+	s.Equal(txListValidator.HintOK, hint)
+
+	// The invalid transaction is forgotten once it leaves the pool.
+	delete(poolContent, invalidSender)
+
+	txLists, _, err = preValidator.splitValid(splitter, poolContent, nil)
+	s.Nil(err)
+	s.Equal([][]*types.Transaction{{validTx}}, txLists)
+	s.Empty(preValidator.invalidTxs)
+}
@@ -0,0 +1,298 @@
+package proposer
+
+import (
+	"container/heap"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/les/utils"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// All built-in transaction ordering strategies.
+const (
+	TxOrderingSenderNonce    = "sender"
+	TxOrderingMaxTip         = "maxTip"
+	TxOrderingFirstSeen      = "fifo"
+	TxOrderingWeightedRandom = "weightedRandom"
+)
+
+// TxOrderingStrategy decides in which order the pending transactions will be filled
+// into the transactions lists to propose. Implementations *MUST* keep the transactions
+// from a same sender sorted by nonce.
+type TxOrderingStrategy interface {
+	// Name returns the name of the strategy.
+	Name() string
+	// Order returns an iterator over the given per-sender transactions lists, each
+	// list is sorted by nonce.
+	Order(txLists rpc.TxLists, baseFee *big.Int) TxIterator
+}
+
+// TxIterator iterates the pending transactions in the order decided by a
+// TxOrderingStrategy.
+type TxIterator interface {
+	// Peek returns the next transaction, or nil if there is no more transaction.
+	Peek() *types.Transaction
+	// Shift replaces the current best transaction with the next one from the same sender.
+	Shift()
+	// Pop removes the current best transaction and all the remaining transactions
+	// from the same sender.
+	Pop()
+}
+
+// NewTxOrderingStrategy creates a new built-in TxOrderingStrategy instance with the given name.
+func NewTxOrderingStrategy(name string) (TxOrderingStrategy, error) {
+	switch name {
+	case "", TxOrderingSenderNonce:
+		return new(senderNonceOrdering), nil
+	case TxOrderingMaxTip:
+		return new(maxTipOrdering), nil
+	case TxOrderingFirstSeen:
+		return newFirstSeenOrdering(), nil
+	case TxOrderingWeightedRandom:
+		return new(weightedRandomOrdering), nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering strategy: %s", name)
+	}
+}
+
+// senderNonceOrdering proposes the transactions sender by sender, each sender's
+// transactions are sorted by nonce, which is the default strategy.
+type senderNonceOrdering struct{}
+
+// Name implements the TxOrderingStrategy interface.
+func (o *senderNonceOrdering) Name() string { return TxOrderingSenderNonce }
+
+// Order implements the TxOrderingStrategy interface.
+func (o *senderNonceOrdering) Order(txLists rpc.TxLists, _ *big.Int) TxIterator {
+	return newSequentialTxIterator(txLists)
+}
+
+// maxTipOrdering always proposes the transaction with the max effective tip
+// among all senders' next transactions first. The transactions whose fee cap
+// is below the base fee, and all the following ones from the same sender, are
+// ignored since they can't be included.
+type maxTipOrdering struct{}
+
+// Name implements the TxOrderingStrategy interface.
+func (o *maxTipOrdering) Name() string { return TxOrderingMaxTip }
+
+// Order implements the TxOrderingStrategy interface.
+func (o *maxTipOrdering) Order(txLists rpc.TxLists, baseFee *big.Int) TxIterator {
+	if baseFee != nil {
+		filtered := make(rpc.TxLists, 0, len(txLists))
+		for _, txList := range txLists {
+			for i, tx := range txList {
+				if tx.GasFeeCapIntCmp(baseFee) < 0 {
+					txList = txList[:i]
+					break
+				}
+			}
+			filtered = append(filtered, txList)
+		}
+		txLists = filtered
+	}
+
+	return newHeadTxIterator(txLists, func(a, b *types.Transaction) bool {
+		return a.EffectiveGasTipCmp(b, baseFee) > 0
+	})
+}
+
+// firstSeenOrdering proposes the transaction which has been seen first by the
+// proposer among all senders' next transactions first.
+type firstSeenOrdering struct {
+	firstSeen map[common.Hash]time.Time
+}
+
+// newFirstSeenOrdering creates a new firstSeenOrdering instance.
+func newFirstSeenOrdering() *firstSeenOrdering {
+	return &firstSeenOrdering{firstSeen: make(map[common.Hash]time.Time)}
+}
+
+// Name implements the TxOrderingStrategy interface.
+func (o *firstSeenOrdering) Name() string { return TxOrderingFirstSeen }
+
+// Order implements the TxOrderingStrategy interface.
+func (o *firstSeenOrdering) Order(txLists rpc.TxLists, _ *big.Int) TxIterator {
+	var (
+		now       = time.Now()
+		firstSeen = make(map[common.Hash]time.Time, txLists.Len())
+	)
+
+	// Only keep the transactions which are still in the pool, to avoid the
+	// records growing forever.
+	for _, txList := range txLists {
+		for _, tx := range txList {
+			seenAt, ok := o.firstSeen[tx.Hash()]
+			if !ok {
+				seenAt = now
+			}
+			firstSeen[tx.Hash()] = seenAt
+		}
+	}
+	o.firstSeen = firstSeen
+
+	return newHeadTxIterator(txLists, func(a, b *types.Transaction) bool {
+		return firstSeen[a.Hash()].Before(firstSeen[b.Hash()])
+	})
+}
+
+// weightedRandomOrdering does a weighted shuffle for the senders, each sender's
+// accumulated transactions gas price will be used as the weight.
+type weightedRandomOrdering struct{}
+
+// Name implements the TxOrderingStrategy interface.
+func (o *weightedRandomOrdering) Name() string { return TxOrderingWeightedRandom }
+
+// Order implements the TxOrderingStrategy interface.
+func (o *weightedRandomOrdering) Order(txLists rpc.TxLists, _ *big.Int) TxIterator {
+	return newSequentialTxIterator(weightedShuffle(txLists))
+}
+
+// weightedShuffle does a weighted shuffle for the given transactions, each transaction's
+// gas price will be used as the weight.
+func weightedShuffle(txLists []types.Transactions) []types.Transactions {
+	shuffled := make([]types.Transactions, 0)
+
+	selector := utils.NewWeightedRandomSelect(func(i interface{}) uint64 {
+		var weight uint64 = 1
+		for _, tx := range txLists[i.(int)] {
+			weight += tx.GasPrice().Uint64()
+		}
+		return weight
+	})
+
+	for i := range txLists {
+		selector.Update(i)
+	}
+
+	for range txLists {
+		idx := selector.Choose().(int)
+		shuffled = append(shuffled, txLists[idx])
+		selector.Remove(idx)
+	}
+
+	return shuffled
+}
+
+// sequentialTxIterator iterates the given transactions lists one by one.
+type sequentialTxIterator struct {
+	txLists []types.Transactions
+}
+
+// newSequentialTxIterator creates a new sequentialTxIterator instance.
+func newSequentialTxIterator(txLists []types.Transactions) *sequentialTxIterator {
+	it := &sequentialTxIterator{txLists: txLists}
+	it.skipEmpty()
+	return it
+}
+
+// Peek implements the TxIterator interface.
+func (it *sequentialTxIterator) Peek() *types.Transaction {
+	if len(it.txLists) == 0 {
+		return nil
+	}
+	return it.txLists[0][0]
+}
+
+// Shift implements the TxIterator interface.
+func (it *sequentialTxIterator) Shift() {
+	if len(it.txLists) == 0 {
+		return
+	}
+	it.txLists[0] = it.txLists[0][1:]
+	it.skipEmpty()
+}
+
+// Pop implements the TxIterator interface.
+func (it *sequentialTxIterator) Pop() {
+	if len(it.txLists) == 0 {
+		return
+	}
+	it.txLists = it.txLists[1:]
+	it.skipEmpty()
+}
+
+// skipEmpty removes all leading empty transactions lists.
+func (it *sequentialTxIterator) skipEmpty() {
+	for len(it.txLists) > 0 && len(it.txLists[0]) == 0 {
+		it.txLists = it.txLists[1:]
+	}
+}
+
+// headTxIterator always returns the best head transaction among all the given
+// transactions lists, based on the given comparison function.
+type headTxIterator struct {
+	heads *txListsByHead
+}
+
+// newHeadTxIterator creates a new headTxIterator instance, `less` reports whether
+// transaction a should be proposed before transaction b.
+func newHeadTxIterator(txLists []types.Transactions, less func(a, b *types.Transaction) bool) *headTxIterator {
+	heads := &txListsByHead{less: less}
+	for _, txList := range txLists {
+		if len(txList) != 0 {
+			heads.txLists = append(heads.txLists, txList)
+		}
+	}
+	heap.Init(heads)
+
+	return &headTxIterator{heads: heads}
+}
+
+// Peek implements the TxIterator interface.
+func (it *headTxIterator) Peek() *types.Transaction {
+	if it.heads.Len() == 0 {
+		return nil
+	}
+	return it.heads.txLists[0][0]
+}
+
+// Shift implements the TxIterator interface.
+func (it *headTxIterator) Shift() {
+	if it.heads.Len() == 0 {
+		return
+	}
+	if it.heads.txLists[0] = it.heads.txLists[0][1:]; len(it.heads.txLists[0]) == 0 {
+		heap.Pop(it.heads)
+		return
+	}
+	heap.Fix(it.heads, 0)
+}
+
+// Pop implements the TxIterator interface.
+func (it *headTxIterator) Pop() {
+	if it.heads.Len() == 0 {
+		return
+	}
+	heap.Pop(it.heads)
+}
+
+// txListsByHead implements the heap.Interface, sorts the transactions lists
+// by their head transactions.
+type txListsByHead struct {
+	txLists []types.Transactions
+	less    func(a, b *types.Transaction) bool
+}
+
+func (h *txListsByHead) Len() int { return len(h.txLists) }
+func (h *txListsByHead) Less(i, j int) bool {
+	return h.less(h.txLists[i][0], h.txLists[j][0])
+}
+func (h *txListsByHead) Swap(i, j int) { h.txLists[i], h.txLists[j] = h.txLists[j], h.txLists[i] }
+
+func (h *txListsByHead) Push(x interface{}) {
+	h.txLists = append(h.txLists, x.(types.Transactions))
+}
+
+func (h *txListsByHead) Pop() interface{} {
+	old := h.txLists
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	h.txLists = old[0 : n-1]
+	return x
+}
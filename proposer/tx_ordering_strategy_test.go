@@ -0,0 +1,184 @@
+package proposer
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+func (s *ProposerTestSuite) TestNewTxOrderingStrategy() {
+	for _, name := range []string{
+		"",
+		TxOrderingSenderNonce,
+		TxOrderingMaxTip,
+		TxOrderingFirstSeen,
+		TxOrderingWeightedRandom,
+	} {
+		strategy, err := NewTxOrderingStrategy(name)
+		s.Nil(err)
+		s.NotNil(strategy)
+	}
+
+	_, err := NewTxOrderingStrategy("unknown")
+	s.NotNil(err)
+}
+
+func (s *ProposerTestSuite) TestMaxTipOrdering() {
+	var (
+		senderA = common.BytesToAddress(testutils.RandomBytes(20))
+		senderB = common.BytesToAddress(testutils.RandomBytes(20))
+		baseFee = big.NewInt(10)
+	)
+
+	poolContent := rpc.PoolContent{
+		senderA: {
+			"0": newDynamicFeeTx(0, 10, 100), // tip: 10
+			"1": newDynamicFeeTx(1, 30, 100), // tip: 30
+		},
+		senderB: {
+			"0": newDynamicFeeTx(0, 20, 100), // tip: 20
+			"1": newDynamicFeeTx(1, 50, 55),  // tip: 45
+		},
+	}
+
+	strategy, err := NewTxOrderingStrategy(TxOrderingMaxTip)
+	s.Nil(err)
+
+	var tips []uint64
+	iter := strategy.Order(poolContent.ToTxLists(), baseFee)
+	for tx := iter.Peek(); tx != nil; tx = iter.Peek() {
+		tips = append(tips, tx.EffectiveGasTipValue(baseFee).Uint64())
+		iter.Shift()
+	}
+
+	// Sender B's second transaction can't be proposed before its first one.
+	s.Equal([]uint64{20, 45, 10, 30}, tips)
+
+	// Popping a transaction drops all the remaining transactions of the same sender.
+	iter = strategy.Order(poolContent.ToTxLists(), baseFee)
+	iter.Pop()
+	s.Equal(uint64(10), iter.Peek().EffectiveGasTipValue(baseFee).Uint64())
+	iter.Shift()
+	s.Equal(uint64(30), iter.Peek().EffectiveGasTipValue(baseFee).Uint64())
+	iter.Shift()
+	s.Nil(iter.Peek())
+
+	// Transactions whose fee cap is below the base fee are ignored, together with
+	// the following transactions from the same sender.
+	tips = nil
+	iter = strategy.Order(poolContent.ToTxLists(), big.NewInt(60))
+	for tx := iter.Peek(); tx != nil; tx = iter.Peek() {
+		tips = append(tips, tx.EffectiveGasTipValue(big.NewInt(60)).Uint64())
+		iter.Shift()
+	}
+	s.Equal([]uint64{20, 10, 30}, tips)
+}
+
+func (s *ProposerTestSuite) TestFirstSeenOrdering() {
+	var (
+		senderA = common.BytesToAddress(testutils.RandomBytes(20))
+		senderB = common.BytesToAddress(testutils.RandomBytes(20))
+		txA0    = newDynamicFeeTx(0, 1, 100)
+		txA1    = newDynamicFeeTx(1, 1, 100)
+		txB0    = newDynamicFeeTx(0, 2, 100)
+	)
+
+	strategy := newFirstSeenOrdering()
+
+	// Only sender A's first transaction has been seen.
+	strategy.firstSeen[txA0.Hash()] = time.Now().Add(-time.Minute)
+
+	iter := strategy.Order(rpc.PoolContent{
+		senderA: {"0": txA0, "1": txA1},
+		senderB: {"0": txB0},
+	}.ToTxLists(), nil)
+
+	s.Equal(txA0.Hash(), iter.Peek().Hash())
+	iter.Shift()
+	s.NotNil(iter.Peek())
+	s.Len(strategy.firstSeen, 3)
+
+	// Transactions which are not in the pool anymore are forgotten.
+	strategy.Order(rpc.PoolContent{senderB: {"0": txB0}}.ToTxLists(), nil)
+	s.Len(strategy.firstSeen, 1)
+}
+
+func (s *ProposerTestSuite) TestWeightedShuffle() {
+	txLists := make([]types.Transactions, 1024)
+
+	for i := 0; i < len(txLists); i++ {
+		var txList types.Transactions
+		for j := 0; j < 1024; j++ {
+			txList = append(txList, types.NewTx(&types.LegacyTx{Nonce: uint64(j), GasPrice: big.NewInt(int64(i))}))
+		}
+		txLists[i] = txList
+	}
+
+	shuffled := weightedShuffle(txLists)
+
+	// Whether is sorted
+	s.False(sort.SliceIsSorted(shuffled, func(i, j int) bool {
+		var (
+			gasA uint64 = 0
+			gasB uint64 = 0
+		)
+
+		for _, tx := range shuffled[i] {
+			gasA += tx.GasPrice().Uint64()
+		}
+
+		for _, tx := range shuffled[j] {
+			gasB += tx.GasPrice().Uint64()
+		}
+
+		return gasA < gasB
+	}))
+
+	for _, txList := range shuffled {
+		s.True(sort.IsSorted(types.TxByNonce(txList)))
+	}
+}
+
+func (s *ProposerTestSuite) TestSplitWithTxOrderingStrategy() {
+	var (
+		senderA = common.BytesToAddress(testutils.RandomBytes(20))
+		senderB = common.BytesToAddress(testutils.RandomBytes(20))
+		txA0    = newDynamicFeeTx(0, 1, 100)
+		txB0    = newDynamicFeeTx(0, 2, 100)
+	)
+
+	strategy, err := NewTxOrderingStrategy(TxOrderingMaxTip)
+	s.Nil(err)
+
+	splitter := &poolContentSplitter{
+		txOrderingStrategy: strategy,
+		maxTxPerBlock:      1,
+		maxGasPerBlock:     txA0.Gas(),
+		maxTxBytesPerBlock: 1024,
+		minTxGasLimit:      txA0.Gas(),
+	}
+
+	splitted := splitter.split(rpc.PoolContent{
+		senderA: {"0": txA0},
+		senderB: {"0": txB0},
+	}, common.Big0)
+
+	s.Equal(2, len(splitted))
+	s.Equal(txB0.Hash(), splitted[0][0].Hash())
+	s.Equal(txA0.Hash(), splitted[1][0].Hash())
+}
+
+// newDynamicFeeTx creates a new unsigned dynamic fee transaction for testing.
+func newDynamicFeeTx(nonce uint64, gasTipCap int64, gasFeeCap int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(gasTipCap),
+		GasFeeCap: big.NewInt(gasFeeCap),
+		Gas:       21000,
+	})
+}
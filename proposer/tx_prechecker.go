@@ -0,0 +1,121 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// precheckPoolContent checks the nonces and the senders' balances of the given pool content against
+// the L2 chain head accounts state, and returns a copy without the transactions failing the checks.
+// The transactions are not executed, so the ones failing for any other reason are still kept.
+func (p *Proposer) precheckPoolContent(
+	ctx context.Context,
+	poolContent rpc.PoolContent,
+	l2Head *types.Header,
+) (rpc.PoolContent, error) {
+	senders := make([]common.Address, 0, len(poolContent))
+	for sender := range poolContent {
+		senders = append(senders, sender)
+	}
+
+	states, err := p.rpc.L2AccountsState(ctx, senders, l2Head.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L2 accounts state: %w", err)
+	}
+
+	return precheckTxs(poolContent, states, l2Head.BaseFee), nil
+}
+
+// precheckTxs checks the nonce and balance of each sender's transactions in nonce order against
+// the given accounts state, the transactions with stale nonces are dropped, and the transactions
+// after a nonce gap or an insufficient balance are dropped together with the same sender's
+// transactions with larger nonces. The reverted transactions are still included in the block,
+// so they are kept.
+func precheckTxs(
+	poolContent rpc.PoolContent,
+	states map[common.Address]*rpc.AccountState,
+	baseFee *big.Int,
+) rpc.PoolContent {
+	prechecked := make(rpc.PoolContent, len(poolContent))
+
+	for sender, txs := range poolContent {
+		state, ok := states[sender]
+		if !ok {
+			log.Warn("Missing L2 account state, skip its pending transactions", "sender", sender)
+			metrics.ProposerPrecheckDropsCounter.Inc(int64(len(txs)))
+			continue
+		}
+
+		sorted := make(types.Transactions, 0, len(txs))
+		for _, tx := range txs {
+			sorted = append(sorted, tx)
+		}
+		sort.Sort(types.TxByNonce(sorted))
+
+		var (
+			nonce     = state.Nonce
+			balance   = new(big.Int).Set(state.Balance)
+			senderTxs = make(map[string]*types.Transaction)
+		)
+		for i, tx := range sorted {
+			if tx.Nonce() < nonce {
+				log.Debug("Drop pending transaction with stale nonce", "hash", tx.Hash(), "nonce", tx.Nonce())
+				metrics.ProposerPrecheckDropsCounter.Inc(1)
+				continue
+			}
+
+			reason := ""
+			if tx.Nonce() > nonce {
+				reason = "nonce gap"
+			} else if balance.Cmp(tx.Cost()) < 0 {
+				// Same as the L2 node's check before buying the gas.
+				reason = "insufficient balance"
+			}
+
+			if reason != "" {
+				log.Debug(
+					"Drop pending transactions failing the precheck",
+					"sender", sender,
+					"hash", tx.Hash(),
+					"nonce", tx.Nonce(),
+					"count", len(sorted)-i,
+					"reason", reason,
+				)
+				metrics.ProposerPrecheckDropsCounter.Inc(int64(len(sorted) - i))
+				break
+			}
+
+			// Assume all the gas is used, which is the worst case.
+			balance.Sub(balance, effectiveCost(tx, baseFee))
+			senderTxs[fmt.Sprint(tx.Nonce())] = tx
+			nonce++
+		}
+
+		if len(senderTxs) != 0 {
+			prechecked[sender] = senderTxs
+		}
+	}
+
+	return prechecked
+}
+
+// effectiveCost returns the maximum cost of the given transaction in a block with the given
+// base fee, i.e. gas * min(gasFeeCap, baseFee + gasTipCap) + value.
+func effectiveCost(tx *types.Transaction, baseFee *big.Int) *big.Int {
+	gasPrice := tx.GasFeeCap()
+	if baseFee != nil {
+		if tip := tx.EffectiveGasTipValue(baseFee); tip.Sign() >= 0 {
+			gasPrice = new(big.Int).Add(baseFee, tip)
+		}
+	}
+
+	return new(big.Int).Add(new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.Gas())), tx.Value())
+}
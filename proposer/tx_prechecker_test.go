@@ -0,0 +1,70 @@
+package proposer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/testutils"
+)
+
+func (s *ProposerTestSuite) TestPrecheckTxs() {
+	var (
+		senderA = common.BytesToAddress(testutils.RandomBytes(20))
+		senderB = common.BytesToAddress(testutils.RandomBytes(20))
+		senderC = common.BytesToAddress(testutils.RandomBytes(20))
+		senderD = common.BytesToAddress(testutils.RandomBytes(20))
+		baseFee = big.NewInt(1)
+	)
+
+	newTx := func(nonce uint64, value int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			Gas:       21000,
+			GasFeeCap: big.NewInt(10),
+			GasTipCap: big.NewInt(1),
+			Value:     big.NewInt(value),
+		})
+	}
+
+	var (
+		staleTx = newTx(0, 0)
+		txA1    = newTx(1, 0)
+		txA2    = newTx(2, 0)
+		txB1    = newTx(1, 0)
+		txB3    = newTx(3, 0)
+		txC0    = newTx(0, 0)
+		txC1    = newTx(1, 100_000)
+		txD0    = newTx(0, 0)
+	)
+
+	prechecked := precheckTxs(rpc.PoolContent{
+		// Stale nonce
+		senderA: {"0": staleTx, "1": txA1, "2": txA2},
+		// Nonce gap
+		senderB: {"1": txB1, "3": txB3},
+		// Insufficient balance for the second transaction, which is checked with the gas fee cap,
+		// while the first transaction only costs the effective gas price.
+		senderC: {"0": txC0, "1": txC1},
+		// Missing account state
+		senderD: {"0": txD0},
+	}, map[common.Address]*rpc.AccountState{
+		senderA: {Nonce: 1, Balance: big.NewInt(1_000_000)},
+		senderB: {Nonce: 1, Balance: big.NewInt(1_000_000)},
+		senderC: {Nonce: 0, Balance: big.NewInt(21000*2 + 21000*10 + 99_999)},
+	}, baseFee)
+
+	s.Equal(rpc.PoolContent{
+		senderA: {"1": txA1, "2": txA2},
+		senderB: {"1": txB1},
+		senderC: {"0": txC0},
+	}, prechecked)
+
+	// Enough balance for both transactions of sender C.
+	prechecked = precheckTxs(rpc.PoolContent{senderC: {"0": txC0, "1": txC1}}, map[common.Address]*rpc.AccountState{
+		senderC: {Nonce: 0, Balance: big.NewInt(21000*2 + 21000*10 + 100_000)},
+	}, baseFee)
+
+	s.Equal(rpc.PoolContent{senderC: {"0": txC0, "1": txC1}}, prechecked)
+}
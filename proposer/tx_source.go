@@ -0,0 +1,345 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// All built-in pending transactions sources.
+const (
+	TxSourcePolling   = "polling"
+	TxSourceStreaming = "streaming"
+)
+
+// Interval between the full resyncs of the streaming source's local pending transactions set,
+// to drop the transactions evicted from the L2 node's transaction pool.
+var streamingTxSourceResyncInterval = 5 * time.Minute
+
+// TxSource provides the L2 pending transactions to propose.
+type TxSource interface {
+	// Name returns the name of the source.
+	Name() string
+	// Start starts keeping the pending transactions up to date in background, until
+	// the given context is canceled.
+	Start(ctx context.Context) error
+	// Close waits for the background goroutines to exit.
+	Close()
+	// PendingContent returns the pending transactions, grouped by sender and nonce.
+	PendingContent(ctx context.Context) (rpc.PoolContent, error)
+}
+
+// NewTxSource creates a new built-in TxSource instance with the given name, the streaming source
+// shares the given watcher's new pending transactions subscription.
+func NewTxSource(name string, cli *rpc.Client, pendingTxsWatcher *pendingTxsWatcher) (TxSource, error) {
+	switch name {
+	case "", TxSourcePolling:
+		return &pollingTxSource{rpc: cli}, nil
+	case TxSourceStreaming:
+		return newStreamingTxSource(cli, pendingTxsWatcher), nil
+	default:
+		return nil, fmt.Errorf("unknown transactions source: %s", name)
+	}
+}
+
+// pollingTxSource fetches the whole L2 node's transaction pool content every time,
+// which is the default source.
+type pollingTxSource struct {
+	rpc *rpc.Client
+}
+
+// Name implements the TxSource interface.
+func (s *pollingTxSource) Name() string { return TxSourcePolling }
+
+// Start implements the TxSource interface.
+func (s *pollingTxSource) Start(ctx context.Context) error { return nil }
+
+// Close implements the TxSource interface.
+func (s *pollingTxSource) Close() {}
+
+// PendingContent implements the TxSource interface.
+func (s *pollingTxSource) PendingContent(ctx context.Context) (rpc.PoolContent, error) {
+	pending, _, err := s.rpc.L2PoolContent(ctx)
+	return pending, err
+}
+
+// streamingTxSource keeps a local pending transactions set up to date through the L2 node's new
+// pending transactions subscription, and removes the transactions mined in the new L2 blocks, so
+// a proposing epoch doesn't need to fetch the whole transaction pool content. The transactions
+// evicted from the L2 node's transaction pool are dropped by the reconciliation after each new
+// L2 block.
+type streamingTxSource struct {
+	rpc               *rpc.Client
+	signer            types.Signer
+	pendingTxsWatcher *pendingTxsWatcher
+
+	mu          sync.RWMutex
+	pending     map[common.Address]map[uint64]*types.Transaction
+	minedNonces map[common.Address]uint64 // Next nonce of the senders whose transactions have been mined
+
+	resyncCh chan struct{}
+	wg       sync.WaitGroup
+}
+
+// newStreamingTxSource creates a new streamingTxSource instance.
+func newStreamingTxSource(cli *rpc.Client, pendingTxsWatcher *pendingTxsWatcher) *streamingTxSource {
+	return &streamingTxSource{
+		rpc:               cli,
+		signer:            types.LatestSignerForChainID(cli.L2ChainID),
+		pendingTxsWatcher: pendingTxsWatcher,
+		pending:           make(map[common.Address]map[uint64]*types.Transaction),
+		minedNonces:       make(map[common.Address]uint64),
+		resyncCh:          make(chan struct{}, 1),
+	}
+}
+
+// Name implements the TxSource interface.
+func (s *streamingTxSource) Name() string { return TxSourceStreaming }
+
+// Start implements the TxSource interface.
+func (s *streamingTxSource) Start(ctx context.Context) error {
+	var (
+		pendingTxsCh   = make(chan []*types.Transaction, 128)
+		resubscribeCh  = make(chan struct{}, 1)
+		headCh         = make(chan *types.Header, 16)
+		pendingTxsSub  = s.pendingTxsWatcher.subscribe(pendingTxsCh)
+		resubscribeSub = s.pendingTxsWatcher.subscribeResubscriptions(resubscribeCh)
+	)
+
+	headsSub := event.ResubscribeErr(
+		backoff.DefaultMaxInterval,
+		func(ctx context.Context, err error) (event.Subscription, error) {
+			if err != nil {
+				log.Warn("Failed to subscribe L2 new heads, try resubscribing", "error", err)
+			}
+
+			return s.watchHeads(ctx, headCh)
+		},
+	)
+
+	// The transactions missed between the initial sync and the subscriptions will be
+	// added by the next resync.
+	if err := s.resync(ctx); err != nil {
+		pendingTxsSub.Unsubscribe()
+		resubscribeSub.Unsubscribe()
+		headsSub.Unsubscribe()
+		return err
+	}
+
+	s.pendingTxsWatcher.start(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		ticker := time.NewTicker(streamingTxSourceResyncInterval)
+		defer func() {
+			ticker.Stop()
+			pendingTxsSub.Unsubscribe()
+			resubscribeSub.Unsubscribe()
+			headsSub.Unsubscribe()
+			s.wg.Done()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case txs := <-pendingTxsCh:
+				for _, tx := range txs {
+					s.addPendingTx(tx)
+				}
+			case <-resubscribeCh:
+				// Some transactions might be missed before resubscribing.
+				s.requestResync()
+			case head := <-headCh:
+				block, err := s.rpc.L2.BlockByHash(ctx, head.Hash())
+				if err != nil {
+					log.Warn("Failed to fetch new L2 block, resync pending transactions", "error", err)
+					s.requestResync()
+					continue
+				}
+
+				s.onMinedTxs(block.Transactions())
+				s.reconcile(ctx)
+			case <-s.resyncCh:
+				if err := s.resync(ctx); err != nil {
+					log.Error("Failed to resync pending transactions", "error", err)
+				}
+			case <-ticker.C:
+				if err := s.resync(ctx); err != nil {
+					log.Error("Failed to resync pending transactions", "error", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close implements the TxSource interface.
+func (s *streamingTxSource) Close() {
+	s.wg.Wait()
+}
+
+// PendingContent implements the TxSource interface.
+func (s *streamingTxSource) PendingContent(ctx context.Context) (rpc.PoolContent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		content = make(rpc.PoolContent, len(s.pending))
+		count   int
+	)
+	for sender, txs := range s.pending {
+		senderTxs := make(map[string]*types.Transaction, len(txs))
+		for nonce, tx := range txs {
+			senderTxs[strconv.FormatUint(nonce, 10)] = tx
+		}
+		content[sender] = senderTxs
+		count += len(txs)
+	}
+
+	metrics.ProposerStreamedTxsGauge.Update(int64(count))
+
+	return content, nil
+}
+
+// resync replaces the local pending transactions set with the L2 node's transaction pool content.
+func (s *streamingTxSource) resync(ctx context.Context) error {
+	content, _, err := s.rpc.L2PoolContent(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch transaction pool content: %w", err)
+	}
+
+	pending := make(map[common.Address]map[uint64]*types.Transaction, len(content))
+	for sender, txs := range content {
+		senderTxs := make(map[uint64]*types.Transaction, len(txs))
+		for _, tx := range txs {
+			senderTxs[tx.Nonce()] = tx
+		}
+		pending[sender] = senderTxs
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = pending
+	s.minedNonces = make(map[common.Address]uint64)
+
+	log.Debug("Pending transactions resynced", "senders", len(pending))
+
+	return nil
+}
+
+// reconcile resyncs the local pending transactions set if it has more transactions than the L2 node's
+// pending ones, which means some transactions have been evicted from the L2 node's transaction pool.
+func (s *streamingTxSource) reconcile(ctx context.Context) {
+	pending, _, err := s.rpc.L2PoolStatus(ctx)
+	if err != nil {
+		log.Warn("Failed to fetch transaction pool status", "error", err)
+		return
+	}
+
+	if count := s.count(); uint64(count) > pending {
+		log.Debug("Pending transactions evicted, resync pending transactions", "local", count, "pending", pending)
+		s.requestResync()
+	}
+}
+
+// count returns the number of the local pending transactions.
+func (s *streamingTxSource) count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int
+	for _, txs := range s.pending {
+		count += len(txs)
+	}
+
+	return count
+}
+
+// requestResync asks the background goroutine to resync the pending transactions set.
+func (s *streamingTxSource) requestResync() {
+	select {
+	case s.resyncCh <- struct{}{}:
+	default:
+	}
+}
+
+// addPendingTx adds a new pending transaction into the local set, a transaction with the same
+// sender and nonce will be replaced.
+func (s *streamingTxSource) addPendingTx(tx *types.Transaction) {
+	sender, err := types.Sender(s.signer, tx)
+	if err != nil {
+		log.Debug("Failed to recover new pending transaction sender", "hash", tx.Hash(), "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if nonce, ok := s.minedNonces[sender]; ok && tx.Nonce() < nonce {
+		return
+	}
+
+	if _, ok := s.pending[sender]; !ok {
+		s.pending[sender] = make(map[uint64]*types.Transaction)
+	}
+	s.pending[sender][tx.Nonce()] = tx
+}
+
+// onMinedTxs removes the given mined transactions, and the same senders' transactions with
+// smaller nonces, which can't be mined anymore, from the local set.
+func (s *streamingTxSource) onMinedTxs(txs types.Transactions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tx := range txs {
+		sender, err := types.Sender(s.signer, tx)
+		if err != nil {
+			continue
+		}
+
+		if nonce, ok := s.minedNonces[sender]; !ok || tx.Nonce()+1 > nonce {
+			s.minedNonces[sender] = tx.Nonce() + 1
+		}
+
+		for nonce := range s.pending[sender] {
+			if nonce <= tx.Nonce() {
+				delete(s.pending[sender], nonce)
+			}
+		}
+
+		if len(s.pending[sender]) == 0 {
+			delete(s.pending, sender)
+		}
+	}
+}
+
+// watchHeads watches the L2 node's new heads.
+func (s *streamingTxSource) watchHeads(ctx context.Context, ch chan<- *types.Header) (event.Subscription, error) {
+	sub, err := s.rpc.L2.SubscribeNewHead(ctx, ch)
+	if err != nil {
+		log.Error("Create L2 new heads subscription error", "error", err)
+		return nil, err
+	}
+
+	defer sub.Unsubscribe()
+
+	select {
+	case err := <-sub.Err():
+		return sub, err
+	case <-ctx.Done():
+		return sub, nil
+	}
+}
@@ -0,0 +1,101 @@
+package proposer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+func (s *ProposerTestSuite) TestNewTxSource() {
+	for _, name := range []string{"", TxSourcePolling, TxSourceStreaming} {
+		source, err := NewTxSource(name, &rpc.Client{L2ChainID: common.Big1}, nil)
+		s.Nil(err)
+		s.NotEmpty(source.Name())
+	}
+
+	_, err := NewTxSource("unknown", &rpc.Client{L2ChainID: common.Big1}, nil)
+	s.NotNil(err)
+}
+
+func (s *ProposerTestSuite) TestStreamingTxSource() {
+	var (
+		chainID = big.NewInt(167)
+		source  = newStreamingTxSource(&rpc.Client{L2ChainID: chainID}, nil)
+	)
+
+	privKeyA, err := crypto.GenerateKey()
+	s.Nil(err)
+	privKeyB, err := crypto.GenerateKey()
+	s.Nil(err)
+
+	signTx := func(nonce uint64, gasTipCap int64) *types.Transaction {
+		tx, err := types.SignTx(
+			types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, Gas: 21000, GasTipCap: big.NewInt(gasTipCap)}),
+			types.LatestSignerForChainID(chainID),
+			privKeyA,
+		)
+		s.Nil(err)
+		return tx
+	}
+
+	var (
+		senderA = crypto.PubkeyToAddress(privKeyA.PublicKey)
+		txA0    = signTx(0, 1)
+		txA1    = signTx(1, 1)
+		txA2    = signTx(2, 1)
+	)
+
+	txB0, err := types.SignTx(
+		types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Gas: 21000}),
+		types.LatestSignerForChainID(chainID),
+		privKeyB,
+	)
+	s.Nil(err)
+
+	// Full transactions streamed, the queued messages are handled at once.
+	msgCh := make(chan json.RawMessage, 4)
+	for _, tx := range []*types.Transaction{txA0, txA1, txA2, txB0} {
+		msg, err := json.Marshal(tx)
+		s.Nil(err)
+		msgCh <- msg
+	}
+
+	msgs := drainMessages(msgCh, <-msgCh)
+	s.Len(msgs, 4)
+
+	for _, tx := range newPendingTxsWatcher(source.rpc).decodeMessages(context.Background(), msgs) {
+		source.addPendingTx(tx)
+	}
+
+	content, err := source.PendingContent(context.Background())
+	s.Nil(err)
+	s.Equal(4, content.ToTxLists().Len())
+
+	// Replaced by a transaction with the same nonce.
+	replacement := signTx(1, 2)
+	source.addPendingTx(replacement)
+
+	content, err = source.PendingContent(context.Background())
+	s.Nil(err)
+	s.Equal(replacement.Hash(), content[senderA]["1"].Hash())
+
+	// Mined transactions are removed, with the same sender's smaller nonces.
+	source.onMinedTxs(types.Transactions{replacement, txB0})
+
+	content, err = source.PendingContent(context.Background())
+	s.Nil(err)
+	s.Equal(1, content.ToTxLists().Len())
+	s.Equal(txA2.Hash(), content[senderA]["2"].Hash())
+
+	// Already mined transactions are ignored.
+	source.addPendingTx(txA0)
+
+	content, err = source.PendingContent(context.Background())
+	s.Nil(err)
+	s.Equal(1, content.ToTxLists().Len())
+}
@@ -0,0 +1,277 @@
+package prover
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/metrics"
+	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
+)
+
+// Criteria to rank the unproven blocks by.
+const (
+	SelectByAge             = "age"          // Older blocks first, since TaikoL1 verifies the blocks in order
+	SelectByReward          = "reward"       // Blocks with the fewest existing proofs first, a proxy of the reward
+	SelectOwnProposersFirst = "ownProposers" // Blocks proposed by our own proposers first
+)
+
+// Maximum number of the unproven blocks to rank in one proving operation.
+var maxSelectionCandidates = 64
+
+// blockCandidate is an unproven block which can be selected to prove.
+type blockCandidate struct {
+	event     *bindings.TaikoL1ClientBlockProposed
+	proposeTx *types.Transaction // TaikoL1.proposeBlock transaction, only set if needed
+	proposer  common.Address     // Sender of the TaikoL1.proposeBlock transaction, only set if needed
+	provers   int                // Number of the existing proofs of the block
+}
+
+// blockSelectionCache keeps the block selection results between the proving operations, so the
+// BlockProven events are only scanned once, and the skipped blocks are not checked again.
+type blockSelectionCache struct {
+	provenIDs     map[uint64]bool // IDs of the blocks proved according to the scanned BlockProven events
+	provenScanned uint64          // Next L1 height to scan the BlockProven events from
+	skippedIDs    map[uint64]bool // IDs of the blocks skipped, since proved by us or having enough proofs
+}
+
+// prune removes the cached blocks which are not greater than the given verified block ID.
+func (c *blockSelectionCache) prune(latestVerifiedID uint64) {
+	for id := range c.provenIDs {
+		if id <= latestVerifiedID {
+			delete(c.provenIDs, id)
+		}
+	}
+	for id := range c.skippedIDs {
+		if id <= latestVerifiedID {
+			delete(c.skippedIDs, id)
+		}
+	}
+}
+
+// selectBlocks collects the unproven blocks proposed since the L1 cursor, skipping the ones which have
+// already been proved by us or have enough proofs, then ranks them by the configured criteria. The L1
+// height of the last iterated BlockProposed event is returned too.
+func (p *Prover) selectBlocks(ctx context.Context) ([]*blockCandidate, uint64, error) {
+	_, _, latestVerifiedID, _, err := p.rpc.TaikoL1.GetStateVariables(nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := p.scanProvenBlocks(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		candidates []*blockCandidate
+		lastHeight = p.l1Current
+	)
+
+	iter, err := eventIterator.NewBlockProposedIterator(ctx, &eventIterator.BlockProposedIteratorConfig{
+		Client:      p.rpc.L1,
+		TaikoL1:     p.rpc.TaikoL1,
+		StartHeight: new(big.Int).SetUint64(p.l1Current),
+		OnBlockProposedEvent: func(
+			ctx context.Context,
+			event *bindings.TaikoL1ClientBlockProposed,
+			end eventIterator.EndBlockProposeEventIterFunc,
+		) error {
+			lastHeight = event.Raw.BlockNumber
+
+			candidate, err := p.selectCandidate(ctx, event, latestVerifiedID)
+			if err != nil || candidate == nil {
+				return err
+			}
+
+			if candidates = append(candidates, candidate); len(candidates) >= maxSelectionCandidates {
+				end()
+			}
+
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := iter.Iter(); err != nil {
+		return nil, 0, err
+	}
+
+	rankCandidates(candidates, p.cfg.SelectionCriteria, p.cfg.OwnProposers)
+
+	return candidates, lastHeight, nil
+}
+
+// selectCandidate checks whether the given proposed block should be proved, returns nil if the block
+// has been verified, proved by us or has enough proofs. The skipped blocks are cached, so they are only
+// checked and logged once.
+func (p *Prover) selectCandidate(
+	ctx context.Context,
+	event *bindings.TaikoL1ClientBlockProposed,
+	latestVerifiedID uint64,
+) (*blockCandidate, error) {
+	if event.Id.Uint64() <= latestVerifiedID || p.selection.skippedIDs[event.Id.Uint64()] {
+		return nil, nil
+	}
+
+	candidate := &blockCandidate{event: event}
+	if p.selection.provenIDs[event.Id.Uint64()] {
+		provers, err := p.getBlockProvers(ctx, event.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if reason := skipProvenBlock(provers, p.cfg.L1ProverSigner.Address(), p.maxProofsPerBlock()); reason != "" {
+			log.Info("Skip proven block", "blockID", event.Id, "reason", reason, "proofs", len(provers))
+			metrics.ProverSkippedProvenBlockCounter.Inc(1)
+
+			if p.selection.skippedIDs == nil {
+				p.selection.skippedIDs = make(map[uint64]bool)
+			}
+			p.selection.skippedIDs[event.Id.Uint64()] = true
+
+			return nil, nil
+		}
+		candidate.provers = len(provers)
+	}
+
+	if p.cfg.SelectionCriteria == SelectOwnProposersFirst {
+		tx, err := p.rpc.L1.TransactionInBlock(ctx, event.Raw.BlockHash, event.Raw.TxIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		if candidate.proposer, err = types.Sender(types.LatestSignerForChainID(p.rpc.L1ChainID), tx); err != nil {
+			return nil, err
+		}
+		candidate.proposeTx = tx
+	}
+
+	return candidate, nil
+}
+
+// skipProvenBlock returns the reason to skip a proven block with the given provers, empty if the block
+// should still be proved.
+func skipProvenBlock(provers []common.Address, proverAddr common.Address, maxProofs int) string {
+	for _, prover := range provers {
+		if prover == proverAddr {
+			return "proved by current prover"
+		}
+	}
+
+	if maxProofs > 0 && len(provers) >= maxProofs {
+		return "enough proofs"
+	}
+
+	return ""
+}
+
+// rankCandidates sorts the given candidates by the given criteria, the older blocks come first on ties.
+//
+// TaikoL1 shares the reward of a fork choice among its provers, so the expected reward is estimated by
+// the share we would get, the fewer existing proofs the higher. It's only a proxy, the actual reward
+// also depends on the proof time and the fee of the block, which are not known before the block is proved.
+func rankCandidates(candidates []*blockCandidate, criteria string, ownProposers []common.Address) {
+	isOwn := func(c *blockCandidate) bool {
+		for _, proposer := range ownProposers {
+			if c.proposer == proposer {
+				return true
+			}
+		}
+		return false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+
+		switch criteria {
+		case SelectByReward:
+			if a.provers != b.provers {
+				return a.provers < b.provers
+			}
+		case SelectOwnProposersFirst:
+			if ownA, ownB := isOwn(a), isOwn(b); ownA != ownB {
+				return ownA
+			}
+		}
+
+		return a.event.Id.Cmp(b.event.Id) < 0
+	})
+}
+
+// scanProvenBlocks caches the IDs of the blocks which have been proved, according to the TaikoL1.BlockProven
+// events emitted since the L1 cursor, the L1 blocks scanned by the previous proving operations are skipped.
+func (p *Prover) scanProvenBlocks(ctx context.Context) error {
+	head, err := p.rpc.L1.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	start := p.l1Current
+	if p.selection.provenScanned > start {
+		start = p.selection.provenScanned
+	}
+	if start > head {
+		return nil
+	}
+
+	if p.selection.provenIDs == nil {
+		p.selection.provenIDs = make(map[uint64]bool)
+	}
+
+	iter, err := eventIterator.NewBlockProvenIterator(ctx, &eventIterator.BlockProvenIteratorConfig{
+		Client:      p.rpc.L1,
+		TaikoL1:     p.rpc.TaikoL1,
+		StartHeight: new(big.Int).SetUint64(start),
+		EndHeight:   new(big.Int).SetUint64(head),
+		OnBlockProvenEvent: func(
+			ctx context.Context,
+			e *bindings.TaikoL1ClientBlockProven,
+			end eventIterator.EndBlockProvenEventIterFunc,
+		) error {
+			p.selection.provenIDs[e.Id.Uint64()] = true
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := iter.Iter(); err != nil {
+		return err
+	}
+	p.selection.provenScanned = head + 1
+
+	return nil
+}
+
+// getBlockProvers returns the provers of the given block's fork choice, whose parent is the
+// L2 node's canonical one. Returns nil if the block has not been inserted by the driver yet.
+func (p *Prover) getBlockProvers(ctx context.Context, blockID *big.Int) ([]common.Address, error) {
+	l1Origin, err := p.rpc.L2.L1OriginByID(ctx, blockID)
+	if err != nil {
+		log.Debug("L1Origin of block not found", "blockID", blockID, "error", err)
+		return nil, nil
+	}
+
+	header, err := p.rpc.L2.HeaderByHash(ctx, l1Origin.L2BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.rpc.TaikoL1.GetBlockProvers(nil, blockID, header.ParentHash)
+}
+
+// maxProofsPerBlock returns the number of proofs, with which a block is skipped.
+func (p *Prover) maxProofsPerBlock() int {
+	if p.cfg.SelectionMaxProofs != 0 && p.cfg.SelectionMaxProofs < p.maxProofsPerForkChoice {
+		return int(p.cfg.SelectionMaxProofs)
+	}
+
+	return int(p.maxProofsPerForkChoice)
+}
@@ -0,0 +1,94 @@
+package prover
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestRankCandidates(t *testing.T) {
+	own := common.BytesToAddress([]byte{0x01})
+	newCandidates := func() []*blockCandidate {
+		return []*blockCandidate{
+			{event: &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(3)}, proposer: own, provers: 0},
+			{event: &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(1)}, provers: 2},
+			{event: &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(4)}, provers: 1},
+			{event: &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(2)}, proposer: own, provers: 1},
+		}
+	}
+
+	for _, testCase := range []struct {
+		criteria string
+		expected []int64
+	}{
+		{SelectByAge, []int64{1, 2, 3, 4}},
+		{SelectByReward, []int64{3, 2, 4, 1}},
+		{SelectOwnProposersFirst, []int64{2, 3, 1, 4}},
+	} {
+		candidates := newCandidates()
+		rankCandidates(candidates, testCase.criteria, []common.Address{own})
+
+		var ids []int64
+		for _, candidate := range candidates {
+			ids = append(ids, candidate.event.Id.Int64())
+		}
+		require.Equal(t, testCase.expected, ids, testCase.criteria)
+	}
+}
+
+func TestMaxProofsPerBlock(t *testing.T) {
+	p := &Prover{cfg: &Config{}, maxProofsPerForkChoice: 5}
+	require.Equal(t, 5, p.maxProofsPerBlock())
+
+	p.cfg.SelectionMaxProofs = 2
+	require.Equal(t, 2, p.maxProofsPerBlock())
+
+	p.cfg.SelectionMaxProofs = 10
+	require.Equal(t, 5, p.maxProofsPerBlock())
+}
+
+func TestSkipProvenBlock(t *testing.T) {
+	var (
+		prover = common.BytesToAddress([]byte{0x01})
+		others = []common.Address{common.BytesToAddress([]byte{0x02}), common.BytesToAddress([]byte{0x03})}
+	)
+
+	require.Empty(t, skipProvenBlock(nil, prover, 2))
+	require.Empty(t, skipProvenBlock(others[:1], prover, 2))
+	require.Equal(t, "proved by current prover", skipProvenBlock([]common.Address{others[0], prover}, prover, 0))
+	require.Equal(t, "enough proofs", skipProvenBlock(others, prover, 2))
+	require.Empty(t, skipProvenBlock(others, prover, 0))
+}
+
+func TestSelectCandidate(t *testing.T) {
+	p := &Prover{cfg: &Config{SelectionCriteria: SelectByAge}}
+	p.selection.skippedIDs = map[uint64]bool{5: true, 6: true}
+	p.selection.provenIDs = map[uint64]bool{3: true}
+
+	newEvent := func(id int64) *bindings.TaikoL1ClientBlockProposed {
+		return &bindings.TaikoL1ClientBlockProposed{Id: big.NewInt(id)}
+	}
+
+	// Verified, or skipped by the previous proving operations.
+	for _, id := range []int64{1, 2, 5} {
+		candidate, err := p.selectCandidate(context.Background(), newEvent(id), 2)
+		require.Nil(t, err)
+		require.Nil(t, candidate, id)
+	}
+
+	// Not proven yet.
+	candidate, err := p.selectCandidate(context.Background(), newEvent(4), 2)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(4), candidate.event.Id)
+	require.Zero(t, candidate.provers)
+	require.Nil(t, candidate.proposeTx)
+
+	// Cached blocks pruned once verified.
+	p.selection.prune(5)
+	require.Equal(t, map[uint64]bool{6: true}, p.selection.skippedIDs)
+	require.Empty(t, p.selection.provenIDs)
+}
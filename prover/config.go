@@ -1,12 +1,14 @@
 package prover
 
 import (
-	"crypto/ecdsa"
 	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
+	feeStrategy "github.com/taikoxyz/taiko-client/pkg/fee_strategy"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	"github.com/urfave/cli/v2"
 )
 
@@ -16,19 +18,81 @@ type Config struct {
 	L2Endpoint          string
 	TaikoL1Address      common.Address
 	TaikoL2Address      common.Address
-	L1ProverPrivKey     *ecdsa.PrivateKey
-	ZKEvmRpcdEndpoint   string
+	L1ProverSigner      signer.Signer
+	ZKEvmRpcdEndpoints  []string
 	ZkEvmRpcdParamsPath string
+	FeeStrategy         *feeStrategy.Config
+	JobStorePath        string
+	ProofConcurrency    uint64
+	SelectionCriteria   string
+	SelectionMaxProofs  uint64
+	OwnProposers        []common.Address
+	CompressedTxLists   bool
 	Dummy               bool
 }
 
 // NewConfigFromCliContext creates a new config instance from command line flags.
 func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
-	l1ProverPrivKeyStr := c.String(flags.L1ProverPrivKey.Name)
-
-	l1ProverPrivKey, err := crypto.ToECDSA(common.Hex2Bytes(l1ProverPrivKeyStr))
+	l1ProverSigner, err := signer.New(c.Context, &signer.Config{
+		PrivKey:              c.String(flags.L1ProverPrivKey.Name),
+		KeystorePath:         c.String(flags.L1ProverKeystore.Name),
+		KeystorePasswordFile: c.String(flags.L1ProverKeystorePassword.Name),
+		RemoteSignerEndpoint: c.String(flags.L1ProverRemoteSigner.Name),
+		RemoteSignerAddress:  c.String(flags.L1ProverAddress.Name),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid L1 prover private key: %w", err)
+		return nil, fmt.Errorf("invalid L1 prover signer: %w", err)
+	}
+
+	var maxGasFeeCap *big.Int
+	if s := c.String(flags.FeeMaxGasFeeCap.Name); s != "" {
+		var ok bool
+		if maxGasFeeCap, ok = new(big.Int).SetString(s, 10); !ok {
+			return nil, fmt.Errorf("invalid max gas fee cap: %s", s)
+		}
+	}
+
+	feeStrategyConfig := &feeStrategy.Config{
+		BaseFeeMultiplier: c.Float64(flags.FeeBaseFeeMultiplier.Name),
+		TipPercentile:     c.Float64(flags.FeeTipPercentile.Name),
+		MaxGasFeeCap:      maxGasFeeCap,
+		Legacy:            c.Bool(flags.FeeLegacy.Name),
+	}
+	if err := feeStrategyConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fee strategy: %w", err)
+	}
+
+	selectionCriteria := c.String(flags.SelectionCriteria.Name)
+	switch selectionCriteria {
+	case "":
+		selectionCriteria = SelectByAge
+	case SelectByAge, SelectByReward, SelectOwnProposersFirst:
+	default:
+		return nil, fmt.Errorf("invalid block selection criteria: %s", selectionCriteria)
+	}
+
+	var ownProposers []common.Address
+	if s := c.String(flags.SelectionOwnProposers.Name); s != "" {
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); !common.IsHexAddress(item) {
+				return nil, fmt.Errorf("invalid own proposer address: %s", item)
+			}
+			ownProposers = append(ownProposers, common.HexToAddress(item))
+		}
+	}
+
+	if selectionCriteria == SelectOwnProposersFirst && len(ownProposers) == 0 {
+		return nil, fmt.Errorf("no own proposer addresses for block selection criteria: %s", selectionCriteria)
+	}
+	if selectionCriteria != SelectOwnProposersFirst && len(ownProposers) != 0 {
+		return nil, fmt.Errorf("own proposer addresses are not used by block selection criteria: %s", selectionCriteria)
+	}
+
+	var rpcdEndpoints []string
+	for _, endpoint := range strings.Split(c.String(flags.ZkEvmRpcdEndpoint.Name), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			rpcdEndpoints = append(rpcdEndpoints, endpoint)
+		}
 	}
 
 	return &Config{
@@ -36,9 +100,16 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		L2Endpoint:          c.String(flags.L2NodeEndpoint.Name),
 		TaikoL1Address:      common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
 		TaikoL2Address:      common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
-		L1ProverPrivKey:     l1ProverPrivKey,
-		ZKEvmRpcdEndpoint:   c.String(flags.ZkEvmRpcdEndpoint.Name),
+		L1ProverSigner:      l1ProverSigner,
+		ZKEvmRpcdEndpoints:  rpcdEndpoints,
 		ZkEvmRpcdParamsPath: c.String(flags.ZkEvmRpcdParamsPath.Name),
+		FeeStrategy:         feeStrategyConfig,
+		JobStorePath:        c.String(flags.JobStorePath.Name),
+		ProofConcurrency:    c.Uint64(flags.ProofConcurrency.Name),
+		SelectionCriteria:   selectionCriteria,
+		SelectionMaxProofs:  c.Uint64(flags.SelectionMaxProofs.Name),
+		OwnProposers:        ownProposers,
+		CompressedTxLists:   c.Bool(flags.CompressedTxLists.Name),
 		Dummy:               c.Bool(flags.Dummy.Name),
 	}, nil
 }
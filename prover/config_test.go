@@ -3,8 +3,10 @@ package prover
 import (
 	"context"
 	"os"
+	"testing"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/cmd/flags"
 	"github.com/urfave/cli/v2"
@@ -23,7 +25,15 @@ func (s *ProverTestSuite) TestNewConfigFromCliContext() {
 		&cli.StringFlag{Name: flags.TaikoL1Address.Name},
 		&cli.StringFlag{Name: flags.TaikoL2Address.Name},
 		&cli.StringFlag{Name: flags.L1ProverPrivKey.Name},
+		&cli.StringFlag{Name: flags.ZkEvmRpcdEndpoint.Name},
 		&cli.BoolFlag{Name: flags.Dummy.Name},
+		&cli.Float64Flag{Name: flags.FeeBaseFeeMultiplier.Name},
+		&cli.StringFlag{Name: flags.FeeMaxGasFeeCap.Name},
+		&cli.StringFlag{Name: flags.JobStorePath.Name},
+		&cli.Uint64Flag{Name: flags.ProofConcurrency.Name},
+		&cli.StringFlag{Name: flags.SelectionCriteria.Name},
+		&cli.StringFlag{Name: flags.SelectionOwnProposers.Name},
+		&cli.Uint64Flag{Name: flags.SelectionMaxProofs.Name},
 	}
 	app.Action = func(ctx *cli.Context) error {
 		c, err := NewConfigFromCliContext(ctx)
@@ -32,8 +42,16 @@ func (s *ProverTestSuite) TestNewConfigFromCliContext() {
 		s.Equal(l2Endpoint, c.L2Endpoint)
 		s.Equal(taikoL1, c.TaikoL1Address.String())
 		s.Equal(taikoL2, c.TaikoL2Address.String())
-		s.Equal(bindings.GoldenTouchAddress, crypto.PubkeyToAddress(c.L1ProverPrivKey.PublicKey))
+		s.Equal(bindings.GoldenTouchAddress, c.L1ProverSigner.Address())
+		s.Equal([]string{"http://localhost:18545", "http://localhost:28545"}, c.ZKEvmRpcdEndpoints)
 		s.True(c.Dummy)
+		s.Equal(1.5, c.FeeStrategy.BaseFeeMultiplier)
+		s.Equal(uint64(1000000000000), c.FeeStrategy.MaxGasFeeCap.Uint64())
+		s.Equal("/tmp/prover-jobs", c.JobStorePath)
+		s.Equal(uint64(4), c.ProofConcurrency)
+		s.Equal(SelectOwnProposersFirst, c.SelectionCriteria)
+		s.Equal([]common.Address{bindings.GoldenTouchAddress}, c.OwnProposers)
+		s.Equal(uint64(2), c.SelectionMaxProofs)
 		s.Nil(new(Prover).InitFromCli(context.Background(), ctx))
 
 		return err
@@ -46,6 +64,43 @@ func (s *ProverTestSuite) TestNewConfigFromCliContext() {
 		"-" + flags.TaikoL1Address.Name, taikoL1,
 		"-" + flags.TaikoL2Address.Name, taikoL2,
 		"-" + flags.L1ProverPrivKey.Name, bindings.GoldenTouchPrivKey[2:],
+		"-" + flags.ZkEvmRpcdEndpoint.Name, "http://localhost:18545, http://localhost:28545",
 		"-" + flags.Dummy.Name,
+		"-" + flags.FeeBaseFeeMultiplier.Name, "1.5",
+		"-" + flags.FeeMaxGasFeeCap.Name, "1000000000000",
+		"-" + flags.JobStorePath.Name, "/tmp/prover-jobs",
+		"-" + flags.ProofConcurrency.Name, "4",
+		"-" + flags.SelectionCriteria.Name, SelectOwnProposersFirst,
+		"-" + flags.SelectionOwnProposers.Name, bindings.GoldenTouchAddress.Hex(),
+		"-" + flags.SelectionMaxProofs.Name, "2",
 	}))
 }
+
+func TestNewConfigFromCliContextSelection(t *testing.T) {
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: flags.L1ProverPrivKey.Name},
+		&cli.StringFlag{Name: flags.SelectionCriteria.Name},
+		&cli.StringFlag{Name: flags.SelectionOwnProposers.Name},
+	}
+	app.Action = func(ctx *cli.Context) error {
+		_, err := NewConfigFromCliContext(ctx)
+		return err
+	}
+
+	run := func(criteria string, ownProposers string) error {
+		return app.Run([]string{
+			"TestNewConfigFromCliContextSelection",
+			"-" + flags.L1ProverPrivKey.Name, bindings.GoldenTouchPrivKey[2:],
+			"-" + flags.SelectionCriteria.Name, criteria,
+			"-" + flags.SelectionOwnProposers.Name, ownProposers,
+		})
+	}
+
+	require.Nil(t, run(SelectByAge, ""))
+	require.Nil(t, run(SelectOwnProposersFirst, bindings.GoldenTouchAddress.Hex()))
+	require.ErrorContains(t, run("fee", ""), "invalid block selection criteria")
+	require.ErrorContains(t, run(SelectOwnProposersFirst, ""), "no own proposer addresses")
+	require.ErrorContains(t, run(SelectByReward, bindings.GoldenTouchAddress.Hex()), "not used by block selection")
+	require.ErrorContains(t, run(SelectByAge, "0x01"), "invalid own proposer address")
+}
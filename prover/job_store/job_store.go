@@ -0,0 +1,271 @@
+package job_store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/taikoxyz/taiko-client/prover/producer"
+)
+
+// LevelDB cache size (in MB) and file handles of the on-disk job store.
+const (
+	dbCache   = 16
+	dbHandles = 16
+)
+
+// Key prefix of the stored proving jobs.
+var jobKeyPrefix = []byte("job-")
+
+// Backoff of retrying the failed and expired jobs, doubled after each failure.
+var (
+	retryBackoff    = 30 * time.Second
+	maxRetryBackoff = 30 * time.Minute
+)
+
+// Status is the lifecycle status of a proving job.
+type Status uint8
+
+// All proving job statuses, in lifecycle order, the failed and expired jobs are retried.
+const (
+	StatusRequested     Status = iota + 1 // Proof requested from the proof producer
+	StatusProofReceived                   // Proof received, but not submitted to TaikoL1 yet
+	StatusSubmitted                       // Proof transaction sent to TaikoL1
+	StatusConfirmed                       // Proof transaction executed successfully
+	StatusFailed                          // Proof failed to be generated or submitted
+	StatusExpired                         // Proof not generated in time
+)
+
+// String implements the fmt.Stringer interface.
+func (s Status) String() string {
+	switch s {
+	case StatusRequested:
+		return "requested"
+	case StatusProofReceived:
+		return "proofReceived"
+	case StatusSubmitted:
+		return "submitted"
+	case StatusConfirmed:
+		return "confirmed"
+	case StatusFailed:
+		return "failed"
+	case StatusExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// Job is the proving job of a proposed L2 block.
+type Job struct {
+	BlockID      uint64                        `json:"blockID"`
+	Valid        bool                          `json:"valid"`    // Proving the block valid or invalid
+	L1Height     uint64                        `json:"l1Height"` // L1 height of the BlockProposed event
+	Status       Status                        `json:"status"`
+	ProofOptions *producer.ProofRequestOptions `json:"proofOptions"`
+	Header       *types.Header                 `json:"header"` // Header of the L2 block (or throwaway block) to prove
+	ZkProof      []byte                        `json:"zkProof,omitempty"`
+	TxHash       common.Hash                   `json:"txHash,omitempty"` // Proof transaction hash, once submitted
+	Task         string                        `json:"task,omitempty"`   // Proof computation task, to resume polling it
+	Attempts     uint64                        `json:"attempts"`         // Number of the failures
+	RetryAt      int64                         `json:"retryAt"`          // Unix time to retry the job after failures
+}
+
+// Retryable returns whether the job has failed or expired, and should be retried.
+func (j *Job) Retryable() bool {
+	return j.Status == StatusFailed || j.Status == StatusExpired
+}
+
+// Fail marks the job failed or expired by the given status, and schedules its retry with exponential
+// backoff, returns the backoff.
+func (j *Job) Fail(status Status) time.Duration {
+	j.Status = status
+	j.Attempts++
+
+	backoff := retryBackoff
+	for i := uint64(1); i < j.Attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	j.RetryAt = time.Now().Add(backoff).Unix()
+
+	return backoff
+}
+
+// BackingOff returns whether the job is failed or expired, and waiting for its retry.
+func (j *Job) BackingOff() bool {
+	return j.Retryable() && time.Now().Unix() < j.RetryAt
+}
+
+// ProofWithHeader returns the received proof of the job, nil if not received yet.
+func (j *Job) ProofWithHeader() *producer.ProofWithHeader {
+	if len(j.ZkProof) == 0 {
+		return nil
+	}
+
+	return &producer.ProofWithHeader{
+		BlockID: new(big.Int).SetUint64(j.BlockID),
+		Header:  j.Header,
+		ZkProof: j.ZkProof,
+	}
+}
+
+// JobStore persists the prover's proving jobs in a key-value database, so the prover can resume
+// them after restarts.
+type JobStore struct {
+	db ethdb.KeyValueStore
+	mu sync.Mutex // Serializes the jobs updates
+}
+
+// New creates a new JobStore instance, the jobs are stored in a LevelDB database in the given
+// directory, or kept in memory only if the path is empty.
+func New(path string) (*JobStore, error) {
+	if path == "" {
+		return &JobStore{db: memorydb.New()}, nil
+	}
+
+	db, err := leveldb.New(path, dbCache, dbHandles, "prover/jobs/", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job store database: %w", err)
+	}
+
+	return &JobStore{db: db}, nil
+}
+
+// Put stores the given job, replacing the stored job of the same block.
+func (s *JobStore) Put(job *Job) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode proving job: %w", err)
+	}
+
+	return s.db.Put(jobKey(job.BlockID), b)
+}
+
+// Get returns the stored job of the given block, nil if not found.
+func (s *JobStore) Get(blockID uint64) (*Job, error) {
+	has, err := s.db.Has(jobKey(blockID))
+	if err != nil || !has {
+		return nil, err
+	}
+
+	b, err := s.db.Get(jobKey(blockID))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJob(b)
+}
+
+// UpdateStatus updates the status of the stored job of the given block, and calls the given
+// function, if not nil, to update the other fields.
+func (s *JobStore) UpdateStatus(blockID uint64, status Status, update func(job *Job)) error {
+	return s.Update(blockID, func(job *Job) {
+		job.Status = status
+		if update != nil {
+			update(job)
+		}
+	})
+}
+
+// Update updates the stored job of the given block by the given function.
+func (s *JobStore) Update(blockID uint64, update func(job *Job)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.Get(blockID)
+	if err != nil {
+		return err
+	}
+
+	if job == nil {
+		return fmt.Errorf("proving job not found, blockID: %d", blockID)
+	}
+
+	update(job)
+
+	return s.Put(job)
+}
+
+// Delete removes the stored job of the given block.
+func (s *JobStore) Delete(blockID uint64) error {
+	return s.db.Delete(jobKey(blockID))
+}
+
+// Jobs returns all stored jobs, ordered by block ID, since the keys are big-endian encoded.
+func (s *JobStore) Jobs() ([]*Job, error) {
+	it := s.db.NewIterator(jobKeyPrefix, nil)
+	defer it.Release()
+
+	var jobs []*Job
+	for it.Next() {
+		job, err := decodeJob(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Prune removes the stored jobs whose block IDs are not greater than the given block ID.
+func (s *JobStore) Prune(blockID uint64) error {
+	it := s.db.NewIterator(jobKeyPrefix, nil)
+	defer it.Release()
+
+	batch := s.db.NewBatch()
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(jobKeyPrefix):]) > blockID {
+			break
+		}
+
+		if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+			return err
+		}
+	}
+
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// Close closes the underlying database.
+func (s *JobStore) Close() error {
+	return s.db.Close()
+}
+
+// jobKey returns the database key of the given block's job.
+func jobKey(blockID uint64) []byte {
+	key := make([]byte, len(jobKeyPrefix)+8)
+	copy(key, jobKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(jobKeyPrefix):], blockID)
+
+	return key
+}
+
+// decodeJob decodes the given JSON encoded job.
+func decodeJob(b []byte) (*Job, error) {
+	job := new(Job)
+	if err := json.Unmarshal(b, job); err != nil {
+		return nil, fmt.Errorf("failed to decode proving job: %w", err)
+	}
+
+	return job, nil
+}
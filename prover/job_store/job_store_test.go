@@ -0,0 +1,147 @@
+package job_store
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/taiko-client/prover/producer"
+)
+
+func newTestJob(blockID uint64) *Job {
+	header := &types.Header{
+		Difficulty: common.Big0,
+		Number:     new(big.Int).SetUint64(blockID),
+		GasLimit:   1024,
+		Extra:      []byte{},
+	}
+
+	return &Job{
+		BlockID:  blockID,
+		Valid:    true,
+		L1Height: blockID * 2,
+		Status:   StatusRequested,
+		ProofOptions: &producer.ProofRequestOptions{
+			Height:         header.Number,
+			L2NodeEndpoint: "http://localhost:28545",
+			Param:          "/params",
+		},
+		Header: header,
+	}
+}
+
+func TestJobStore(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.Nil(t, err)
+
+	for _, id := range []uint64{3, 1, 256, 2} {
+		require.Nil(t, store.Put(newTestJob(id)))
+	}
+
+	job, err := store.Get(4)
+	require.Nil(t, err)
+	require.Nil(t, job)
+
+	job, err = store.Get(256)
+	require.Nil(t, err)
+	require.Equal(t, uint64(512), job.L1Height)
+	require.Equal(t, StatusRequested, job.Status)
+	require.Equal(t, big.NewInt(256), job.ProofOptions.Height)
+	require.Equal(t, "http://localhost:28545", job.ProofOptions.L2NodeEndpoint)
+	require.Equal(t, job.Header.Hash(), newTestJob(256).Header.Hash())
+	require.Nil(t, job.ProofWithHeader())
+
+	// Proof received and submitted.
+	require.Nil(t, store.UpdateStatus(256, StatusProofReceived, func(job *Job) { job.ZkProof = []byte{0xff} }))
+	txHash := common.BytesToHash([]byte{0x01})
+	require.Nil(t, store.UpdateStatus(256, StatusSubmitted, func(job *Job) { job.TxHash = txHash }))
+	require.NotNil(t, store.UpdateStatus(4, StatusSubmitted, nil))
+
+	job, err = store.Get(256)
+	require.Nil(t, err)
+	require.Equal(t, StatusSubmitted, job.Status)
+	require.Equal(t, txHash, job.TxHash)
+	require.Equal(t, &producer.ProofWithHeader{
+		BlockID: big.NewInt(256),
+		Header:  job.Header,
+		ZkProof: []byte{0xff},
+	}, job.ProofWithHeader())
+	require.False(t, job.Retryable())
+
+	// Proof computation task recorded, then failed, the received proof is kept.
+	require.Nil(t, store.Update(256, func(job *Job) { job.Task = "http://localhost:18545" }))
+	require.Nil(t, store.UpdateStatus(256, StatusFailed, nil))
+
+	job, err = store.Get(256)
+	require.Nil(t, err)
+	require.Equal(t, "http://localhost:18545", job.Task)
+	require.True(t, job.Retryable())
+	require.NotNil(t, job.ProofWithHeader())
+
+	jobs, err := store.Jobs()
+	require.Nil(t, err)
+	require.Equal(t, 4, len(jobs))
+	for i, id := range []uint64{1, 2, 3, 256} {
+		require.Equal(t, id, jobs[i].BlockID)
+	}
+
+	require.Nil(t, store.Delete(3))
+	require.Nil(t, store.Prune(1))
+
+	jobs, err = store.Jobs()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(jobs))
+	require.Equal(t, uint64(2), jobs[0].BlockID)
+	require.Equal(t, uint64(256), jobs[1].BlockID)
+	require.Nil(t, store.Close())
+}
+
+func TestJobFail(t *testing.T) {
+	job := newTestJob(1)
+	require.False(t, job.BackingOff())
+
+	// Backoff doubled after each failure, up to the maximum.
+	for i, expected := range []time.Duration{retryBackoff, 2 * retryBackoff, 4 * retryBackoff} {
+		require.Equal(t, expected, job.Fail(StatusFailed))
+		require.Equal(t, uint64(i+1), job.Attempts)
+		require.True(t, job.BackingOff())
+	}
+
+	job.Attempts = 64
+	require.Equal(t, maxRetryBackoff, job.Fail(StatusExpired))
+	require.Equal(t, StatusExpired, job.Status)
+
+	// Backoff over.
+	job.RetryAt = time.Now().Add(-time.Second).Unix()
+	require.False(t, job.BackingOff())
+	require.True(t, job.Retryable())
+}
+
+func TestJobStoreReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := New(dir)
+	require.Nil(t, err)
+	require.Nil(t, store.Put(newTestJob(1)))
+	require.Nil(t, store.Close())
+
+	store, err = New(dir)
+	require.Nil(t, err)
+	defer store.Close()
+
+	job, err := store.Get(1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), job.BlockID)
+
+	// In memory only.
+	memStore, err := New("")
+	require.Nil(t, err)
+	defer memStore.Close()
+
+	job, err = memStore.Get(1)
+	require.Nil(t, err)
+	require.Nil(t, job)
+}
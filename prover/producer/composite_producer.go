@@ -0,0 +1,386 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+var (
+	errNoHealthyBackend = errors.New("no healthy proof producer backend")
+	errBackendDown      = errors.New("proof producer backend is unhealthy")
+)
+
+// Health check configurations of CompositeProducer.
+var (
+	healthCheckInterval = 30 * time.Second
+	healthCheckTimeout  = 10 * time.Second
+)
+
+// HealthCheckedProducer is a ProofProducer whose health can be checked.
+type HealthCheckedProducer interface {
+	ProofProducer
+	CheckHealth(ctx context.Context) error
+}
+
+// compositeBackend is a backend of CompositeProducer.
+type compositeBackend struct {
+	index    int
+	producer HealthCheckedProducer
+	healthy  bool
+	pending  int           // Number of the proofs being generated by the backend
+	down     chan struct{} // Closed once the backend is found unhealthy
+}
+
+// CompositeProducer balances the proof requests among several ProofProducer backends, each request
+// is handed out to the healthy backend with the fewest proofs being generated. The backends' health is
+// checked on a schedule, if a backend fails a proof, or is found unhealthy in the middle of a proof, the
+// proof is moved to another backend. A proof computation task submitted before is resumed on the backend
+// which knows it, if that backend is still healthy.
+type CompositeProducer struct {
+	mu       sync.Mutex
+	backends []*compositeBackend
+
+	ctx context.Context
+	wg  sync.WaitGroup
+}
+
+// NewZkevmRpcdCompositeProducer creates a new CompositeProducer instance, which balances the proof requests
+// among the given ZKEVM RPCD services.
+func NewZkevmRpcdCompositeProducer(ctx context.Context, rpcdEndpoints []string) (*CompositeProducer, error) {
+	var producers []HealthCheckedProducer
+	for _, endpoint := range rpcdEndpoints {
+		producer, err := newZkevmRpcdProducer(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ZKEVM RPCD producer %s: %w", endpoint, err)
+		}
+
+		producers = append(producers, producer)
+	}
+
+	return NewCompositeProducer(ctx, producers...)
+}
+
+// NewCompositeProducer creates a new CompositeProducer instance, at least one of the given backends should
+// be healthy. The health checks stop once the given context is canceled.
+func NewCompositeProducer(ctx context.Context, producers ...HealthCheckedProducer) (*CompositeProducer, error) {
+	c := &CompositeProducer{ctx: ctx}
+	for i, producer := range producers {
+		c.backends = append(c.backends, &compositeBackend{
+			index:    i,
+			producer: producer,
+			down:     make(chan struct{}),
+		})
+	}
+
+	c.checkHealth()
+	if c.healthyBackends() == 0 {
+		return nil, errNoHealthyBackend
+	}
+
+	c.wg.Add(1)
+	go c.healthCheckLoop()
+
+	return c, nil
+}
+
+// Close implements the ClosableProducer interface.
+func (c *CompositeProducer) Close() {
+	c.wg.Wait()
+}
+
+// RequestProof implements the ProofProducer interface, if the proof fails to be generated by
+// all healthy backends, a result with the error is delivered.
+func (c *CompositeProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	if c.healthyBackends() == 0 {
+		return errNoHealthyBackend
+	}
+
+	go c.generate(ctx, "", opts, blockID, header, resultCh)
+
+	return nil
+}
+
+// ResumeProof implements the ResumableProducer interface, the task is resumed on the healthy backend which
+// knows it, otherwise the proof is requested again like RequestProof.
+func (c *CompositeProducer) ResumeProof(
+	ctx context.Context,
+	task string,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	if c.healthyBackends() == 0 {
+		return errNoHealthyBackend
+	}
+
+	go c.generate(ctx, task, opts, blockID, header, resultCh)
+
+	return nil
+}
+
+// generate resumes the given task if not empty, and moves the proof among the healthy backends until it
+// is generated or the given context is canceled, then delivers the result.
+func (c *CompositeProducer) generate(
+	ctx context.Context,
+	task string,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) {
+	var (
+		tried = make(map[*compositeBackend]bool)
+		proof []byte
+		err   = errNoHealthyBackend
+	)
+
+	if task != "" {
+		proof, err = c.resume(ctx, task, tried, opts, blockID, header)
+	}
+
+	for err != nil && ctx.Err() == nil {
+		backend := c.acquire(tried)
+		if backend == nil {
+			break
+		}
+		tried[backend] = true
+
+		if proof, err = c.prove(ctx, backend, "", opts, blockID, header); err == nil {
+			break
+		}
+
+		if c.ctx.Err() != nil {
+			return
+		}
+
+		log.Warn(
+			"Proof failed on proof producer backend, move to another backend",
+			"blockID", blockID,
+			"backend", backend.index,
+			"error", err,
+		)
+	}
+
+	select {
+	case resultCh <- &ProofWithHeader{BlockID: blockID, Header: header, ZkProof: proof, Err: err}:
+	case <-c.ctx.Done():
+	}
+}
+
+// resume resumes the given task on the healthy backend which knows it, returns errNoHealthyBackend if
+// there is no such backend.
+func (c *CompositeProducer) resume(
+	ctx context.Context,
+	task string,
+	tried map[*compositeBackend]bool,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+) ([]byte, error) {
+	for _, backend := range c.backends {
+		if _, ok := backend.producer.(ResumableProducer); !ok || !c.acquireBackend(backend) {
+			continue
+		}
+
+		proof, err := c.prove(ctx, backend, task, opts, blockID, header)
+		if errors.Is(err, ErrUnknownTask) {
+			continue
+		}
+
+		tried[backend] = true
+		if err != nil {
+			log.Warn(
+				"Failed to resume proof on proof producer backend",
+				"blockID", blockID,
+				"backend", backend.index,
+				"error", err,
+			)
+		}
+
+		return proof, err
+	}
+
+	return nil, errNoHealthyBackend
+}
+
+// prove requests the proof from the given backend, or resumes the given task if not empty, and waits for
+// it until the backend is found unhealthy. The backend should have been acquired, it's released once the
+// backend delivers the result, so a proof abandoned by an unhealthy backend is canceled but still counted
+// until the backend stops it.
+func (c *CompositeProducer) prove(
+	ctx context.Context,
+	backend *compositeBackend,
+	task string,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+) ([]byte, error) {
+	c.mu.Lock()
+	down := backend.down
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		ch  = make(chan *ProofWithHeader, 1)
+		err error
+	)
+	if task != "" {
+		err = backend.producer.(ResumableProducer).ResumeProof(ctx, task, opts, blockID, header, ch)
+	} else {
+		err = backend.producer.RequestProof(ctx, opts, blockID, header, ch)
+	}
+	if err != nil {
+		c.release(backend)
+		return nil, err
+	}
+
+	select {
+	case res := <-ch:
+		c.release(backend)
+		return res.ZkProof, res.Err
+	case <-down:
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+
+			select {
+			case <-ch:
+			case <-c.ctx.Done():
+			}
+			c.release(backend)
+		}()
+		return nil, errBackendDown
+	case <-c.ctx.Done():
+		c.release(backend)
+		return nil, c.ctx.Err()
+	}
+}
+
+// acquire picks the healthy backend with the fewest proofs being generated, except the given
+// tried ones, returns nil if there is no such backend.
+func (c *CompositeProducer) acquire(tried map[*compositeBackend]bool) *compositeBackend {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var picked *compositeBackend
+	for _, backend := range c.backends {
+		if !backend.healthy || tried[backend] {
+			continue
+		}
+
+		if picked == nil || backend.pending < picked.pending {
+			picked = backend
+		}
+	}
+
+	if picked != nil {
+		picked.pending++
+	}
+
+	return picked
+}
+
+// acquireBackend acquires the given backend if it is healthy.
+func (c *CompositeProducer) acquireBackend(backend *compositeBackend) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if backend.healthy {
+		backend.pending++
+	}
+
+	return backend.healthy
+}
+
+// release marks a proof of the given backend finished.
+func (c *CompositeProducer) release(backend *compositeBackend) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	backend.pending--
+}
+
+// healthCheckLoop checks the backends' health periodically, until the context is canceled.
+func (c *CompositeProducer) healthCheckLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkHealth()
+		}
+	}
+}
+
+// checkHealth checks all backends' health concurrently, and updates their statuses.
+func (c *CompositeProducer) checkHealth() {
+	var wg sync.WaitGroup
+	for _, backend := range c.backends {
+		wg.Add(1)
+		go func(backend *compositeBackend) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(c.ctx, healthCheckTimeout)
+			defer cancel()
+
+			c.setHealthy(backend, backend.producer.CheckHealth(ctx))
+		}(backend)
+	}
+	wg.Wait()
+
+	metrics.ProverHealthyProducerGauge.Update(int64(c.healthyBackends()))
+}
+
+// setHealthy updates the given backend's health status by its health check result.
+func (c *CompositeProducer) setHealthy(backend *compositeBackend, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if healthy := err == nil; healthy != backend.healthy {
+		log.Info("Proof producer backend health changed", "backend", backend.index, "healthy", healthy, "error", err)
+
+		if healthy {
+			backend.down = make(chan struct{})
+		} else {
+			close(backend.down)
+		}
+		backend.healthy = healthy
+	}
+}
+
+// healthyBackends returns the number of the healthy backends.
+func (c *CompositeProducer) healthyBackends() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int
+	for _, backend := range c.backends {
+		if backend.healthy {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,298 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackend is a HealthCheckedProducer whose proofs are generated once released, or failed
+// if `fail` is set.
+type testBackend struct {
+	mu        sync.Mutex
+	unhealthy bool
+	fail      bool
+	requested int
+	release   chan struct{}
+}
+
+// RequestProof implements the ProofProducer interface.
+func (b *testBackend) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.requested++
+	res := &ProofWithHeader{BlockID: blockID, Header: header, ZkProof: []byte{0xff}}
+	if b.fail {
+		res.Err = errors.New("proof computation failed")
+	}
+
+	go func() {
+		select {
+		case <-b.release:
+		case <-ctx.Done():
+			res = &ProofWithHeader{BlockID: blockID, Header: header, Err: ctx.Err()}
+		}
+		resultCh <- res
+	}()
+
+	return nil
+}
+
+// CheckHealth implements the HealthCheckedProducer interface.
+func (b *testBackend) CheckHealth(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.unhealthy {
+		return errRpcdUnhealthy
+	}
+	return nil
+}
+
+func (b *testBackend) setUnhealthy(unhealthy bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.unhealthy = unhealthy
+}
+
+func (b *testBackend) requests() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.requested
+}
+
+func TestCompositeProducerBalance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := NewCompositeProducer(ctx, &testBackend{unhealthy: true})
+	require.ErrorIs(t, err, errNoHealthyBackend)
+
+	a := &testBackend{release: make(chan struct{})}
+	b := &testBackend{release: make(chan struct{})}
+	c := &testBackend{unhealthy: true, release: make(chan struct{})}
+
+	producer, err := NewCompositeProducer(ctx, a, b, c)
+	require.Nil(t, err)
+	require.Equal(t, 2, producer.healthyBackends())
+
+	resCh := make(chan *ProofWithHeader, 3)
+	header := &types.Header{Number: common.Big256}
+
+	// Balanced by the number of proofs being generated, skipping the unhealthy backend.
+	for i := 0; i < 3; i++ {
+		require.Nil(t, producer.RequestProof(ctx, &ProofRequestOptions{}, big.NewInt(int64(i)), header, resCh))
+	}
+	require.Eventually(t, func() bool { return a.requests() == 2 && b.requests() == 1 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, 0, c.requests())
+
+	close(a.release)
+	close(b.release)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case res := <-resCh:
+			require.Nil(t, res.Err)
+		case <-time.After(time.Second):
+			t.Fatal("proof not delivered")
+		}
+	}
+
+	// No healthy backend.
+	a.setUnhealthy(true)
+	b.setUnhealthy(true)
+	producer.checkHealth()
+	require.ErrorIs(
+		t,
+		producer.RequestProof(ctx, &ProofRequestOptions{}, common.Big256, header, resCh),
+		errNoHealthyBackend,
+	)
+}
+
+func TestCompositeProducerFailover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	busy := &testBackend{release: make(chan struct{})}
+	failing := &testBackend{unhealthy: true, fail: true, release: make(chan struct{})}
+	stuck := &testBackend{unhealthy: true, release: make(chan struct{})}
+	close(failing.release)
+
+	producer, err := NewCompositeProducer(ctx, busy, failing, stuck)
+	require.Nil(t, err)
+
+	resCh := make(chan *ProofWithHeader, 3)
+	header := &types.Header{Number: common.Big256}
+
+	pending := func(backend int) int {
+		producer.mu.Lock()
+		defer producer.mu.Unlock()
+
+		return producer.backends[backend].pending
+	}
+
+	// Two proofs being generated by the first backend, while the others are unhealthy.
+	for i := 0; i < 2; i++ {
+		require.Nil(t, producer.RequestProof(ctx, &ProofRequestOptions{}, big.NewInt(int64(i)), header, resCh))
+	}
+	require.Eventually(t, func() bool { return busy.requests() == 2 }, time.Second, 10*time.Millisecond)
+
+	failing.setUnhealthy(false)
+	stuck.setUnhealthy(false)
+	producer.checkHealth()
+
+	// Failed on the second backend, then the third one is found unhealthy in the middle of the proof,
+	// and moved back to the first one.
+	require.Nil(t, producer.RequestProof(ctx, &ProofRequestOptions{}, common.Big256, header, resCh))
+	require.Eventually(t, func() bool { return stuck.requests() == 1 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, failing.requests())
+
+	stuck.setUnhealthy(true)
+	producer.checkHealth()
+
+	require.Eventually(t, func() bool { return busy.requests() == 3 }, time.Second, 10*time.Millisecond)
+	// The abandoned proof is canceled.
+	require.Eventually(t, func() bool { return pending(2) == 0 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, 3, pending(0))
+
+	close(busy.release)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case res := <-resCh:
+			require.Nil(t, res.Err)
+			require.Equal(t, []byte{0xff}, res.ZkProof)
+		case <-time.After(time.Second):
+			t.Fatal("proof not delivered")
+		}
+	}
+	require.Equal(t, 0, pending(0))
+
+	// Canceled by the requester.
+	blocked := make(chan struct{})
+	stuck.mu.Lock()
+	stuck.release = blocked
+	stuck.mu.Unlock()
+	stuck.setUnhealthy(false)
+	producer.checkHealth()
+	busy.setUnhealthy(true)
+	failing.setUnhealthy(true)
+	producer.checkHealth()
+
+	reqCtx, reqCancel := context.WithCancel(ctx)
+	require.Nil(t, producer.RequestProof(reqCtx, &ProofRequestOptions{}, common.Big256, header, resCh))
+	require.Eventually(t, func() bool { return stuck.requests() == 2 }, time.Second, 10*time.Millisecond)
+	reqCancel()
+
+	select {
+	case res := <-resCh:
+		require.ErrorIs(t, res.Err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("proof cancellation not delivered")
+	}
+	require.Equal(t, 1, failing.requests())
+	require.Equal(t, 0, pending(2))
+
+	busy.setUnhealthy(false)
+	failing.setUnhealthy(false)
+	producer.checkHealth()
+	close(blocked)
+
+	// Failed on all backends.
+	for _, backend := range []*testBackend{busy, stuck} {
+		backend.mu.Lock()
+		backend.fail = true
+		backend.mu.Unlock()
+	}
+
+	require.Nil(t, producer.RequestProof(ctx, &ProofRequestOptions{}, common.Big256, header, resCh))
+
+	select {
+	case res := <-resCh:
+		require.NotNil(t, res.Err)
+	case <-time.After(time.Second):
+		t.Fatal("proof failure not delivered")
+	}
+
+	cancel()
+	producer.Close()
+}
+
+func TestNewZkevmRpcdCompositeProducer(t *testing.T) {
+	healthy := httptest.NewServer(&rpcdStub{})
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(&rpcdStub{unhealthy: true})
+	defer unhealthy.Close()
+
+	producer, err := NewZkevmRpcdCompositeProducer(context.Background(), []string{healthy.URL, unhealthy.URL})
+	require.Nil(t, err)
+	require.Equal(t, 1, producer.healthyBackends())
+
+	_, err = NewZkevmRpcdCompositeProducer(context.Background(), []string{unhealthy.URL})
+	require.ErrorIs(t, err, errNoHealthyBackend)
+}
+
+func TestCompositeProducerResumeProof(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		stubs     = []*rpcdStub{{proof: []byte{0x01}}, {proof: []byte{0x02}}}
+		producers []HealthCheckedProducer
+	)
+	for _, stub := range stubs {
+		producer := newTestZkevmRpcdProducer(t, stub)
+		producer.ctx = ctx
+		producers = append(producers, producer)
+	}
+
+	producer, err := NewCompositeProducer(ctx, producers...)
+	require.Nil(t, err)
+
+	resCh := make(chan *ProofWithHeader, 1)
+	header := &types.Header{Number: common.Big256}
+	task := producers[1].(*ZkevmRpcdProducer).RpcdEndpoint
+
+	// Resumed on the backend which knows the task.
+	opts := &ProofRequestOptions{Height: header.Number}
+	require.Nil(t, producer.ResumeProof(ctx, task, opts, common.Big1, header, resCh))
+
+	select {
+	case res := <-resCh:
+		require.Nil(t, res.Err)
+		require.Equal(t, []byte{0x02}, res.ZkProof)
+	case <-time.After(time.Second):
+		t.Fatal("proof not delivered")
+	}
+
+	// Requested again if no backend knows the task.
+	task = ""
+	opts = &ProofRequestOptions{Height: header.Number, OnTaskSubmitted: func(t string) { task = t }}
+	require.Nil(t, producer.ResumeProof(ctx, "http://localhost:18545", opts, common.Big1, header, resCh))
+
+	select {
+	case res := <-resCh:
+		require.Nil(t, res.Err)
+		require.Equal(t, []byte{0x01}, res.ZkProof)
+	case <-time.After(time.Second):
+		t.Fatal("proof not delivered")
+	}
+	require.Equal(t, producers[0].(*ZkevmRpcdProducer).RpcdEndpoint, task)
+}
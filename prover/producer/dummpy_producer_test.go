@@ -1,6 +1,7 @@
 package producer
 
 import (
+	"context"
 	"crypto/rand"
 	"testing"
 	"time"
@@ -34,7 +35,7 @@ func TestRequestProof(t *testing.T) {
 		MixDigest:   randHash(),
 		Nonce:       types.BlockNonce{},
 	}
-	require.Nil(t, dummyProofProducer.RequestProof(&ProofRequestOptions{}, blockID, header, resCh))
+	require.Nil(t, dummyProofProducer.RequestProof(context.Background(), &ProofRequestOptions{}, blockID, header, resCh))
 
 	res := <-resCh
 	require.Equal(t, res.BlockID, blockID)
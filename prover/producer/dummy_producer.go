@@ -1,6 +1,7 @@
 package producer
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
@@ -12,6 +13,7 @@ type DummyProofProducer struct{}
 
 // RequestProof implements the ProofProducer interface.
 func (d *DummyProofProducer) RequestProof(
+	ctx context.Context,
 	opts *ProofRequestOptions,
 	blockID *big.Int,
 	header *types.Header,
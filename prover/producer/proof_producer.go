@@ -1,25 +1,71 @@
 package producer
 
 import (
+	"context"
+	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+var (
+	ErrUnknownTask = errors.New("unknown proof computation task")
+)
+
 // ProofRequestOptions contains all options that need to be passed to zkEVM rpcd service.
 type ProofRequestOptions struct {
 	Height         *big.Int // the block number
 	L2NodeEndpoint string   // the L2 node rpc endpoint url
 	Retry          bool     // retry proof computation if error
 	Param          string   // parameter file to use
+
+	// Called with the identity of the proof computation task once submitted, if not nil
+	OnTaskSubmitted func(task string) `json:"-"`
 }
 
 type ProofWithHeader struct {
 	BlockID *big.Int
 	Header  *types.Header
 	ZkProof []byte
+	Err     error // Not nil if the proof failed to be generated
 }
 
+// ProofProducer generates the proofs of the L2 blocks, a proof generation is canceled once its context
+// is canceled, and a result with the error is still delivered then.
 type ProofProducer interface {
-	RequestProof(opts *ProofRequestOptions, blockID *big.Int, header *types.Header, resultCh chan *ProofWithHeader) error
+	RequestProof(
+		ctx context.Context,
+		opts *ProofRequestOptions,
+		blockID *big.Int,
+		header *types.Header,
+		resultCh chan *ProofWithHeader,
+	) error
+}
+
+// QueuedProducer is a ProofProducer which queues the proof requests until they can be handled.
+type QueuedProducer interface {
+	ProofProducer
+	Queued() int
+}
+
+// ClosableProducer is a ProofProducer which runs background goroutines, Close waits for them to exit
+// after the producer's context is canceled.
+type ClosableProducer interface {
+	ProofProducer
+	Close()
+}
+
+// ResumableProducer is a ProofProducer which can resume polling a proof computation task submitted
+// before a restart, identified by ProofRequestOptions.OnTaskSubmitted, instead of requesting the proof
+// again. ErrUnknownTask is returned if the task is not submitted by the producer.
+type ResumableProducer interface {
+	ProofProducer
+	ResumeProof(
+		ctx context.Context,
+		task string,
+		opts *ProofRequestOptions,
+		blockID *big.Int,
+		header *types.Header,
+		resultCh chan *ProofWithHeader,
+	) error
 }
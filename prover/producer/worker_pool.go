@@ -0,0 +1,239 @@
+package producer
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/metrics"
+)
+
+var (
+	ErrProofRequested = errors.New("proof of the block has been requested")
+)
+
+// proofJob is a proof request waiting to be handed out to the ProofProducer backend.
+type proofJob struct {
+	ctx      context.Context
+	task     string // Proof computation task to resume, if not empty
+	opts     *ProofRequestOptions
+	blockID  *big.Int
+	header   *types.Header
+	resultCh chan *ProofWithHeader
+}
+
+// proofJobQueue is a priority queue of the proof jobs, the job with the lowest block ID comes first.
+type proofJobQueue []*proofJob
+
+func (q proofJobQueue) Len() int            { return len(q) }
+func (q proofJobQueue) Less(i, j int) bool  { return q[i].blockID.Cmp(q[j].blockID) < 0 }
+func (q proofJobQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *proofJobQueue) Push(x interface{}) { *q = append(*q, x.(*proofJob)) }
+func (q *proofJobQueue) Pop() interface{} {
+	old := *q
+	job := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return job
+}
+
+// WorkerPool is a ProofProducer which hands out the proof requests to its ProofProducer backend, with at
+// most `concurrency` proofs being generated at the same time. Use a CompositeProducer backend to balance
+// the proofs among several proof producers.
+//
+// TaikoL1 verifies the blocks strictly in order, so the queued requests are handed out by block ID, the
+// lowest first. While the proofs are delivered once generated, not in block ID order, since TaikoL1 accepts
+// the proofs of different blocks in any order.
+type WorkerPool struct {
+	backend ProofProducer
+
+	mu       sync.Mutex
+	queue    proofJobQueue
+	inFlight map[uint64]struct{} // IDs of the blocks whose proofs are queued or being generated
+	notify   chan struct{}
+
+	ctx context.Context
+	wg  sync.WaitGroup
+}
+
+// NewWorkerPool creates a new WorkerPool instance and starts its workers, which stop once
+// the given context is canceled.
+func NewWorkerPool(ctx context.Context, concurrency uint64, backend ProofProducer) (*WorkerPool, error) {
+	if backend == nil {
+		return nil, errors.New("no proof producer backend")
+	}
+
+	if concurrency == 0 {
+		concurrency = 1
+	}
+
+	p := &WorkerPool{
+		backend:  backend,
+		inFlight: make(map[uint64]struct{}),
+		notify:   make(chan struct{}, concurrency),
+		ctx:      ctx,
+	}
+
+	for i := uint64(0); i < concurrency; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	return p, nil
+}
+
+// RequestProof implements the ProofProducer interface, the request is queued until a worker is available.
+func (p *WorkerPool) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	return p.enqueue(&proofJob{ctx: ctx, opts: opts, blockID: blockID, header: header, resultCh: resultCh})
+}
+
+// ResumeProof implements the ResumableProducer interface, the request is queued until a worker is available,
+// then the task is resumed if the backend knows it, otherwise the proof is requested again.
+func (p *WorkerPool) ResumeProof(
+	ctx context.Context,
+	task string,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	return p.enqueue(&proofJob{
+		ctx:      ctx,
+		task:     task,
+		opts:     opts,
+		blockID:  blockID,
+		header:   header,
+		resultCh: resultCh,
+	})
+}
+
+// enqueue queues the given job, unless the proof of the same block is queued or being generated.
+func (p *WorkerPool) enqueue(job *proofJob) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.inFlight[job.blockID.Uint64()]; ok {
+		return fmt.Errorf("%w, blockID: %d", ErrProofRequested, job.blockID)
+	}
+
+	p.inFlight[job.blockID.Uint64()] = struct{}{}
+	heap.Push(&p.queue, job)
+	metrics.ProverInFlightProofGauge.Update(int64(len(p.inFlight)))
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// InFlight returns whether the proof of the given block is queued or being generated.
+func (p *WorkerPool) InFlight(blockID *big.Int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.inFlight[blockID.Uint64()]
+	return ok
+}
+
+// Queued returns the number of the requests not handed out to the backends yet.
+func (p *WorkerPool) Queued() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.queue.Len()
+}
+
+// Close implements the ClosableProducer interface, it waits for the workers to exit, and closes
+// the backend if it's closable.
+func (p *WorkerPool) Close() {
+	p.wg.Wait()
+
+	if closable, ok := p.backend.(ClosableProducer); ok {
+		closable.Close()
+	}
+}
+
+// work hands out the queued jobs one by one, until the pool's context is canceled.
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+
+	for {
+		job := p.nextJob()
+		if job == nil {
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-p.notify:
+			}
+			continue
+		}
+
+		res := p.generate(job)
+		if res == nil {
+			return
+		}
+
+		p.mu.Lock()
+		delete(p.inFlight, job.blockID.Uint64())
+		metrics.ProverInFlightProofGauge.Update(int64(len(p.inFlight)))
+		p.mu.Unlock()
+
+		select {
+		case job.resultCh <- res:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// nextJob pops the queued job with the lowest block ID, returns nil if the queue is empty.
+func (p *WorkerPool) nextJob() *proofJob {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.queue.Len() == 0 {
+		return nil
+	}
+
+	return heap.Pop(&p.queue).(*proofJob)
+}
+
+// generate requests the given job's proof from the backend and waits for the result, returns nil
+// if the pool's context is canceled meanwhile.
+func (p *WorkerPool) generate(job *proofJob) *ProofWithHeader {
+	var (
+		ch  = make(chan *ProofWithHeader, 1)
+		err = ErrUnknownTask
+	)
+	if resumable, ok := p.backend.(ResumableProducer); ok && job.task != "" {
+		err = resumable.ResumeProof(job.ctx, job.task, job.opts, job.blockID, job.header, ch)
+	}
+	if errors.Is(err, ErrUnknownTask) {
+		err = p.backend.RequestProof(job.ctx, job.opts, job.blockID, job.header, ch)
+	}
+
+	if err != nil {
+		log.Error("Failed to request proof", "blockID", job.blockID, "error", err)
+		return &ProofWithHeader{BlockID: job.blockID, Header: job.header, Err: err}
+	}
+
+	select {
+	case res := <-ch:
+		return res
+	case <-p.ctx.Done():
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingProducer is a ProofProducer backend whose proofs are generated once released,
+// and fail if the block ID is in `failures`.
+type blockingProducer struct {
+	mu        sync.Mutex
+	requested []uint64
+	release   chan struct{}
+	failures  map[uint64]bool
+}
+
+// RequestProof implements the ProofProducer interface.
+func (b *blockingProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	b.mu.Lock()
+	b.requested = append(b.requested, blockID.Uint64())
+	b.mu.Unlock()
+
+	go func() {
+		<-b.release
+		res := &ProofWithHeader{BlockID: blockID, Header: header, ZkProof: []byte{0xff}}
+		if b.failures[blockID.Uint64()] {
+			res.Err = errors.New("proof computation failed")
+		}
+		resultCh <- res
+	}()
+
+	return nil
+}
+
+func (b *blockingProducer) requestedIDs() []uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]uint64{}, b.requested...)
+}
+
+func TestWorkerPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := NewWorkerPool(ctx, 2, nil)
+	require.NotNil(t, err)
+
+	backend := &blockingProducer{release: make(chan struct{}), failures: map[uint64]bool{3: true}}
+	pool, err := NewWorkerPool(ctx, 2, backend)
+	require.Nil(t, err)
+
+	resCh := make(chan *ProofWithHeader, 4)
+	request := func(id int64) error {
+		return pool.RequestProof(ctx, &ProofRequestOptions{}, big.NewInt(id), &types.Header{Number: big.NewInt(id)}, resCh)
+	}
+
+	// Two proofs are generated at the same time, the others are queued.
+	require.Nil(t, request(5))
+	require.Nil(t, request(2))
+	require.Eventually(t, func() bool { return len(backend.requestedIDs()) == 2 }, time.Second, 10*time.Millisecond)
+	require.Nil(t, request(4))
+	require.Nil(t, request(3))
+	require.True(t, errors.Is(request(4), ErrProofRequested))
+	require.True(t, pool.InFlight(big.NewInt(5)))
+	require.Equal(t, 2, pool.Queued())
+
+	// The queued requests are handed out by block ID.
+	backend.release <- struct{}{}
+	require.Eventually(t, func() bool { return len(backend.requestedIDs()) == 3 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(3), backend.requestedIDs()[2])
+
+	close(backend.release)
+
+	results := map[uint64]error{}
+	for i := 0; i < 4; i++ {
+		select {
+		case res := <-resCh:
+			results[res.BlockID.Uint64()] = res.Err
+		case <-time.After(time.Second):
+			t.Fatal("proof not delivered")
+		}
+	}
+
+	require.Equal(t, 4, len(results))
+	require.NotNil(t, results[3])
+	require.Nil(t, results[2])
+	require.Equal(t, 0, pool.Queued())
+	require.False(t, pool.InFlight(big.NewInt(3)))
+
+	// Can be requested again once delivered.
+	require.Nil(t, request(3))
+}
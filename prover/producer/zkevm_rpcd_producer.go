@@ -1,36 +1,123 @@
 package producer
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/taikoxyz/taiko-client/metrics"
 )
 
 var (
 	errRpcdUnhealthy = errors.New("ZKEVM RPCD endpoint is unhealthy")
 )
 
+// Default proof polling configurations of ZkevmRpcdProducer.
+var (
+	defaultProofPollInterval    = 10 * time.Second
+	defaultMaxProofPollInterval = 2 * time.Minute
+	defaultProofTimeout         = 3 * time.Hour
+	defaultMaxProofRetries      = 3
+	defaultSubmitTimeout        = time.Minute
+)
+
+// ZkevmRpcdProducer requests the proofs from a ZKEVM RPCD service, the RPCD `proof` method
+// starts a proof computation task for the given block if not started yet, and returns null
+// until the task is finished, so the same request is sent again to poll the task status.
 type ZkevmRpcdProducer struct {
-	RpcdEndpoint string
+	RpcdEndpoint    string
+	PollInterval    time.Duration // Initial interval between the task status polls, backed off exponentially
+	MaxPollInterval time.Duration // Maximum interval between the task status polls
+	Timeout         time.Duration // Give up a proof after waiting for this duration
+	MaxRetries      int           // Give up a proof after its computation fails these many times
+	SubmitTimeout   time.Duration // Timeout of the proof request submitting call, which blocks the caller
+
+	ctx    context.Context
+	client *gethRPC.Client
+}
+
+// rpcdProofRequest is the parameter of the RPCD `proof` method.
+type rpcdProofRequest struct {
+	Block *big.Int `json:"block"`
+	RPC   string   `json:"rpc"`
+	Retry bool     `json:"retry"`
+	Param string   `json:"param"`
 }
 
-func NewZkevmRpcdProducer(rpcdEndpoint string) (*ZkevmRpcdProducer, error) {
-	resp, err := http.Get(rpcdEndpoint + "/health")
+// rpcdProofResult is the result of the RPCD `proof` method, null if the task is not finished yet.
+type rpcdProofResult struct {
+	Circuit struct {
+		Instance []string      `json:"instance"`
+		Proof    hexutil.Bytes `json:"proof"`
+	} `json:"circuit"`
+}
+
+// NewZkevmRpcdProducer creates a new ZkevmRpcdProducer instance, the proofs polling stops once
+// the given context is canceled.
+func NewZkevmRpcdProducer(ctx context.Context, rpcdEndpoint string) (*ZkevmRpcdProducer, error) {
+	producer, err := newZkevmRpcdProducer(ctx, rpcdEndpoint)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		return nil, errRpcdUnhealthy
+
+	if err := producer.CheckHealth(ctx); err != nil {
+		return nil, err
+	}
+
+	return producer, nil
+}
+
+// newZkevmRpcdProducer creates a new ZkevmRpcdProducer instance without checking the RPCD service's health.
+func newZkevmRpcdProducer(ctx context.Context, rpcdEndpoint string) (*ZkevmRpcdProducer, error) {
+	client, err := gethRPC.DialHTTP(rpcdEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZkevmRpcdProducer{
+		RpcdEndpoint:    rpcdEndpoint,
+		PollInterval:    defaultProofPollInterval,
+		MaxPollInterval: defaultMaxProofPollInterval,
+		Timeout:         defaultProofTimeout,
+		MaxRetries:      defaultMaxProofRetries,
+		SubmitTimeout:   defaultSubmitTimeout,
+		ctx:             ctx,
+		client:          client,
+	}, nil
+}
+
+// CheckHealth implements the HealthCheckedProducer interface.
+func (d *ZkevmRpcdProducer) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.RpcdEndpoint+"/health", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errRpcdUnhealthy
 	}
 
-	return &ZkevmRpcdProducer{RpcdEndpoint: rpcdEndpoint}, nil
+	return nil
 }
 
-// RequestProof implements the ProofProducer interface.
+// RequestProof implements the ProofProducer interface, if the proof fails to be generated, a result
+// with the error is delivered.
 func (d *ZkevmRpcdProducer) RequestProof(
+	ctx context.Context,
 	opts *ProofRequestOptions,
 	blockID *big.Int,
 	header *types.Header,
@@ -43,13 +130,154 @@ func (d *ZkevmRpcdProducer) RequestProof(
 		"hash", header.Hash(),
 	)
 
-	// TODO: call zkevm RPCD to get a proof.
-	go func() {
-		resultCh <- &ProofWithHeader{
-			BlockID: blockID,
-			Header:  header,
-			ZkProof: []byte{0x00},
-		}
-	}()
+	req := newRpcdProofRequest(opts)
+
+	// Submit the proof computation task, the following requests only poll its status.
+	submitCtx, cancel := context.WithTimeout(ctx, d.SubmitTimeout)
+	defer cancel()
+
+	proof, err := d.callProof(submitCtx, req)
+	if err != nil && !isRpcdTaskError(err) {
+		return fmt.Errorf("failed to submit proof request to ZKEVM RPCD: %w", err)
+	}
+
+	// The task is identified by the RPCD endpoint, since RPCD identifies it by the request itself.
+	if opts.OnTaskSubmitted != nil {
+		opts.OnTaskSubmitted(d.RpcdEndpoint)
+	}
+
+	go d.deliverProof(ctx, req, proof, err, blockID, header, resultCh)
+
 	return nil
 }
+
+// ResumeProof implements the ResumableProducer interface, it polls the status of a proof computation
+// task submitted to the same RPCD service before, without submitting it again.
+func (d *ZkevmRpcdProducer) ResumeProof(
+	ctx context.Context,
+	task string,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) error {
+	if task != d.RpcdEndpoint {
+		return ErrUnknownTask
+	}
+
+	log.Info("Resume polling proof from ZKEVM RPCD service", "blockID", blockID, "height", header.Number)
+
+	go d.deliverProof(ctx, newRpcdProofRequest(opts), nil, nil, blockID, header, resultCh)
+
+	return nil
+}
+
+// newRpcdProofRequest creates the RPCD `proof` method parameter from the given options.
+func newRpcdProofRequest(opts *ProofRequestOptions) *rpcdProofRequest {
+	return &rpcdProofRequest{
+		Block: opts.Height,
+		RPC:   opts.L2NodeEndpoint,
+		Retry: opts.Retry,
+		Param: opts.Param,
+	}
+}
+
+// deliverProof waits for the given proof computation task if the proof is not generated yet, then
+// delivers the result. `err` is the error of the last request.
+func (d *ZkevmRpcdProducer) deliverProof(
+	ctx context.Context,
+	req *rpcdProofRequest,
+	proof []byte,
+	err error,
+	blockID *big.Int,
+	header *types.Header,
+	resultCh chan *ProofWithHeader,
+) {
+	if proof == nil {
+		if proof, err = d.waitProof(ctx, req, err); err != nil {
+			log.Error("Failed to get proof from ZKEVM RPCD", "blockID", blockID, "height", req.Block, "error", err)
+			metrics.ProverFailedProofCounter.Inc(1)
+		}
+	}
+
+	if err == nil {
+		log.Info("Proof generated by ZKEVM RPCD", "blockID", blockID, "height", req.Block, "size", len(proof))
+	}
+
+	select {
+	case resultCh <- &ProofWithHeader{BlockID: blockID, Header: header, ZkProof: proof, Err: err}:
+	case <-d.ctx.Done():
+	}
+}
+
+// waitProof polls the status of the given proof computation task with exponential backoff, until the
+// proof is generated or the given context is canceled. If the computation fails, the task will be retried
+// if `req.Retry` is set, and the transient RPC errors are always retried. `lastErr` is the error of the
+// submitting request.
+func (d *ZkevmRpcdProducer) waitProof(ctx context.Context, req *rpcdProofRequest, lastErr error) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout)
+	defer cancel()
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = d.PollInterval
+	b.MaxInterval = d.MaxPollInterval
+	b.MaxElapsedTime = 0
+	b.Reset()
+
+	var failures int
+	for err := lastErr; ; {
+		if err != nil {
+			if !isRpcdTaskError(err) {
+				log.Warn("Failed to poll proof status from ZKEVM RPCD, retry", "height", req.Block, "error", err)
+			} else {
+				failures++
+				if !req.Retry || failures > d.MaxRetries {
+					return nil, fmt.Errorf("proof computation failed %d times: %w", failures, err)
+				}
+
+				log.Warn("Proof computation failed, retry", "height", req.Block, "failures", failures, "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("proof not generated in time: %w", ctx.Err())
+			}
+			return nil, fmt.Errorf("proof request canceled: %w", ctx.Err())
+		case <-d.ctx.Done():
+			return nil, d.ctx.Err()
+		case <-time.After(b.NextBackOff()):
+		}
+
+		var proof []byte
+		if proof, err = d.callProof(ctx, req); err == nil && proof != nil {
+			return proof, nil
+		}
+	}
+}
+
+// callProof calls the RPCD `proof` method, returns nil if the proof is not generated yet.
+func (d *ZkevmRpcdProducer) callProof(ctx context.Context, req *rpcdProofRequest) ([]byte, error) {
+	var result *rpcdProofResult
+	if err := d.client.CallContext(ctx, &result, "proof", req); err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	if len(result.Circuit.Proof) == 0 {
+		return nil, errors.New("empty proof returned by ZKEVM RPCD")
+	}
+
+	return result.Circuit.Proof, nil
+}
+
+// isRpcdTaskError returns whether the given error is a proof computation task error returned
+// by RPCD, instead of a transient transport error.
+func isRpcdTaskError(err error) bool {
+	var rpcErr gethRPC.Error
+	return errors.As(err, &rpcErr)
+}
@@ -1,21 +1,112 @@
 package producer
 
 import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
+// rpcdStub is a local ZKEVM RPCD service, whose proof computation tasks fail `failures` times
+// and then stay pending for `pendingPolls` polls, before returning the proof.
+type rpcdStub struct {
+	mu           sync.Mutex
+	unhealthy    bool
+	failures     int
+	pendingPolls int
+	unavailable  int           // Number of the following requests responded with HTTP 503
+	delay        time.Duration // Delay of the proof requests responses
+	proof        []byte
+	requests     []rpcdProofRequest
+}
+
+func (s *rpcdStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/health" {
+		if s.unhealthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		return
+	}
+
+	if s.unavailable > 0 {
+		s.unavailable--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var msg struct {
+		ID     json.RawMessage    `json:"id"`
+		Method string             `json:"method"`
+		Params []rpcdProofRequest `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil || msg.Method != "proof" || len(msg.Params) != 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	req := msg.Params[0]
+	s.requests = append(s.requests, req)
+
+	time.Sleep(s.delay)
+
+	res := map[string]interface{}{"jsonrpc": "2.0", "id": msg.ID}
+	switch {
+	case s.failures > 0 && (len(s.requests) == 1 || req.Retry):
+		s.failures--
+		res["error"] = map[string]interface{}{"code": -32000, "message": "proof computation failed"}
+	case s.pendingPolls > 0:
+		s.pendingPolls--
+		res["result"] = nil
+	default:
+		res["result"] = map[string]interface{}{
+			"circuit": map[string]interface{}{"instance": []string{"0x01"}, "proof": hexutil.Bytes(s.proof)},
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// newTestZkevmRpcdProducer creates a new ZkevmRpcdProducer instance connected to the given stub,
+// with short polling intervals.
+func newTestZkevmRpcdProducer(t *testing.T, stub *rpcdStub) *ZkevmRpcdProducer {
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+
+	producer, err := NewZkevmRpcdProducer(context.Background(), srv.URL)
+	require.Nil(t, err)
+
+	producer.PollInterval = 10 * time.Millisecond
+	producer.MaxPollInterval = 20 * time.Millisecond
+	producer.Timeout = time.Second
+
+	return producer
+}
+
 func TestNewZkevmRpcdProducer(t *testing.T) {
-	_, err := NewZkevmRpcdProducer("http://localhost:28551")
+	srv := httptest.NewServer(&rpcdStub{unhealthy: true})
+	defer srv.Close()
+
+	_, err := NewZkevmRpcdProducer(context.Background(), srv.URL)
 	require.EqualError(t, err, errRpcdUnhealthy.Error())
+}
 
-	dummyZKEvmProducer, err := NewZkevmRpcdProducer("http://localhost:18545")
-	require.Nil(t, err)
+func TestZkevmRpcdProducerRequestProof(t *testing.T) {
+	stub := &rpcdStub{failures: 1, pendingPolls: 2, proof: randHash().Bytes()}
+	producer := newTestZkevmRpcdProducer(t, stub)
 
 	resCh := make(chan *ProofWithHeader, 1)
 
@@ -36,10 +127,132 @@ func TestNewZkevmRpcdProducer(t *testing.T) {
 		MixDigest:   randHash(),
 		Nonce:       types.BlockNonce{},
 	}
-	require.Nil(t, dummyZKEvmProducer.RequestProof(&ProofRequestOptions{}, blockID, header, resCh))
+	var task string
+	opts := &ProofRequestOptions{
+		Height:          header.Number,
+		L2NodeEndpoint:  "http://localhost:28545",
+		Retry:           true,
+		OnTaskSubmitted: func(t string) { task = t },
+	}
+
+	// Submitted, failed once and retried, then polled while pending and after a transient error.
+	require.Nil(t, producer.RequestProof(context.Background(), opts, blockID, header, resCh))
+	require.Equal(t, producer.RpcdEndpoint, task)
+	stub.mu.Lock()
+	stub.unavailable = 1
+	stub.mu.Unlock()
+
+	select {
+	case res := <-resCh:
+		require.Equal(t, blockID, res.BlockID)
+		require.Equal(t, header, res.Header)
+		require.Equal(t, stub.proof, res.ZkProof)
+	case <-time.After(5 * time.Second):
+		t.Fatal("proof not delivered")
+	}
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	require.Equal(t, 4, len(stub.requests))
+	for _, req := range stub.requests {
+		require.Equal(t, header.Number, req.Block)
+		require.Equal(t, opts.L2NodeEndpoint, req.RPC)
+		require.True(t, req.Retry)
+	}
+}
+
+func TestZkevmRpcdProducerRequestProofFailed(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		header = &types.Header{Number: common.Big256}
+		opts   = &ProofRequestOptions{Height: header.Number}
+	)
+
+	// Proof computation failed without retrying.
+	stub := &rpcdStub{failures: 1, proof: randHash().Bytes()}
+	producer := newTestZkevmRpcdProducer(t, stub)
+
+	resCh := make(chan *ProofWithHeader, 2)
+	require.Nil(t, producer.RequestProof(ctx, opts, common.Big1, header, resCh))
+
+	// Not generated in time.
+	stub = &rpcdStub{pendingPolls: 1000, proof: randHash().Bytes()}
+	producer = newTestZkevmRpcdProducer(t, stub)
+	producer.Timeout = 100 * time.Millisecond
+
+	require.Nil(t, producer.RequestProof(ctx, opts, common.Big2, header, resCh))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case res := <-resCh:
+			require.NotNil(t, res.Err)
+			require.Nil(t, res.ZkProof)
+		case <-time.After(time.Second):
+			t.Fatal("proof failure not delivered")
+		}
+	}
+
+	// RPCD service unavailable when submitting.
+	stub = &rpcdStub{}
+	producer = newTestZkevmRpcdProducer(t, stub)
+	stub.unavailable = 1
+
+	require.NotNil(t, producer.RequestProof(ctx, opts, common.Big3, header, resCh))
+
+	// RPCD service hangs when submitting.
+	stub = &rpcdStub{delay: time.Second}
+	producer = newTestZkevmRpcdProducer(t, stub)
+	producer.SubmitTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	require.NotNil(t, producer.RequestProof(ctx, opts, common.Big3, header, resCh))
+	require.Less(t, time.Since(start), time.Second)
+
+	// Canceled by the requester.
+	stub = &rpcdStub{pendingPolls: 1000}
+	producer = newTestZkevmRpcdProducer(t, stub)
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	require.Nil(t, producer.RequestProof(reqCtx, opts, big.NewInt(4), header, resCh))
+	cancel()
+
+	select {
+	case res := <-resCh:
+		require.ErrorIs(t, res.Err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("proof cancellation not delivered")
+	}
+}
+
+func TestZkevmRpcdProducerResumeProof(t *testing.T) {
+	stub := &rpcdStub{pendingPolls: 1, proof: randHash().Bytes()}
+	producer := newTestZkevmRpcdProducer(t, stub)
+
+	var (
+		ctx    = context.Background()
+		header = &types.Header{Number: common.Big256}
+		opts   = &ProofRequestOptions{Height: header.Number}
+		resCh  = make(chan *ProofWithHeader, 1)
+	)
+
+	// Task submitted to another RPCD service.
+	err := producer.ResumeProof(ctx, "http://localhost:18545", opts, common.Big1, header, resCh)
+	require.ErrorIs(t, err, ErrUnknownTask)
+
+	// Polled until generated, without submitting the task again.
+	require.Nil(t, producer.ResumeProof(ctx, producer.RpcdEndpoint, opts, common.Big1, header, resCh))
+
+	select {
+	case res := <-resCh:
+		require.Nil(t, res.Err)
+		require.Equal(t, stub.proof, res.ZkProof)
+	case <-time.After(5 * time.Second):
+		t.Fatal("proof not delivered")
+	}
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
 
-	res := <-resCh
-	require.Equal(t, res.BlockID, blockID)
-	require.Equal(t, res.Header, header)
-	require.NotEmpty(t, res.ZkProof)
+	require.Equal(t, 2, len(stub.requests))
 }
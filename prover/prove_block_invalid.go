@@ -13,6 +13,7 @@ import (
 	"github.com/taikoxyz/taiko-client/metrics"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+	jobStore "github.com/taikoxyz/taiko-client/prover/job_store"
 	"github.com/taikoxyz/taiko-client/prover/producer"
 )
 
@@ -39,15 +40,12 @@ func (p *Prover) proveBlockInvalid(
 		Param:          p.cfg.ZkEvmRpcdParamsPath,
 	}
 
-	if err := p.proofProducer.RequestProof(
-		proofOpts, event.Id, throwAwayBlock.Header(), p.proveInvalidProofCh,
-	); err != nil {
+	if err := p.requestProof(event, false, proofOpts, throwAwayBlock.Header(), p.proveInvalidProofCh); err != nil {
 		return err
 	}
 
 	metrics.ProverQueuedProofCounter.Inc(1)
 	metrics.ProverQueuedInvalidProofCounter.Inc(1)
-	p.l1Current = event.Raw.BlockNumber
 
 	return nil
 }
@@ -72,6 +70,10 @@ func (p *Prover) submitInvalidBlockProof(
 	metrics.ProverReceivedProofCounter.Inc(1)
 	metrics.ProverReceivedInvalidProofCounter.Inc(1)
 
+	p.updateJobStatus(blockID, jobStore.StatusProofReceived, func(job *jobStore.Job) {
+		job.Header, job.ZkProof = header, zkProof
+	})
+
 	block, err := p.rpc.L2.BlockByHash(ctx, header.Hash())
 	if err != nil {
 		return fmt.Errorf("failed to fetch throwaway block: %w", err)
@@ -124,7 +126,7 @@ func (p *Prover) submitInvalidBlockProof(
 			ExtraData:   header.Extra,
 		},
 		Header: *encoding.FromGethHeader(header),
-		Prover: crypto.PubkeyToAddress(p.cfg.L1ProverPrivKey.PublicKey),
+		Prover: p.cfg.L1ProverSigner.Address(),
 		Proofs: proofs,
 	}
 
@@ -133,7 +135,7 @@ func (p *Prover) submitInvalidBlockProof(
 		return err
 	}
 
-	txOpts, err := p.getProveBlocksTxOpts(ctx, p.rpc.L1)
+	txOpts, err := p.getProveBlocksTxOpts(ctx)
 	if err != nil {
 		return err
 	}
@@ -142,10 +144,12 @@ func (p *Prover) submitInvalidBlockProof(
 	if err != nil {
 		return fmt.Errorf("failed to send TaikoL1.proveBlockInvalid transaction: %w", err)
 	}
+	p.updateJobStatus(blockID, jobStore.StatusSubmitted, func(job *jobStore.Job) { job.TxHash = tx.Hash() })
 
 	if _, err := rpc.WaitReceipt(ctx, p.rpc.L1, tx); err != nil {
 		return fmt.Errorf("failed to wait till transaction executed: %w", err)
 	}
+	p.updateJobStatus(blockID, jobStore.StatusConfirmed, nil)
 
 	log.Info(
 		"❎ New invalid block proved",
@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/metrics"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	jobStore "github.com/taikoxyz/taiko-client/prover/job_store"
 	"github.com/taikoxyz/taiko-client/prover/producer"
 )
 
@@ -38,13 +38,12 @@ func (p *Prover) proveBlockValid(ctx context.Context, event *bindings.TaikoL1Cli
 		Param:          p.cfg.ZkEvmRpcdParamsPath,
 	}
 
-	if err := p.proofProducer.RequestProof(opts, event.Id, header, p.proveValidProofCh); err != nil {
+	if err := p.requestProof(event, true, opts, header, p.proveValidProofCh); err != nil {
 		return err
 	}
 
 	metrics.ProverQueuedProofCounter.Inc(1)
 	metrics.ProverQueuedValidProofCounter.Inc(1)
-	p.l1Current = event.Raw.BlockNumber
 
 	return nil
 }
@@ -66,12 +65,16 @@ func (p *Prover) submitValidBlockProof(ctx context.Context, proofWithHeader *pro
 	metrics.ProverReceivedProofCounter.Inc(1)
 	metrics.ProverReceivedValidProofCounter.Inc(1)
 
+	p.updateJobStatus(blockID, jobStore.StatusProofReceived, func(job *jobStore.Job) {
+		job.Header, job.ZkProof = header, zkProof
+	})
+
 	meta, err := p.rpc.GetBlockMetadataByID(blockID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch L2 block with given block ID %s: %w", blockID, err)
 	}
 
-	txOpts, err := p.getProveBlocksTxOpts(ctx, p.rpc.L1)
+	txOpts, err := p.getProveBlocksTxOpts(ctx)
 	if err != nil {
 		return err
 	}
@@ -131,7 +134,7 @@ func (p *Prover) submitValidBlockProof(ctx context.Context, proofWithHeader *pro
 	evidence := &encoding.TaikoL1Evidence{
 		Meta:   *meta,
 		Header: *encoding.FromGethHeader(header),
-		Prover: crypto.PubkeyToAddress(p.cfg.L1ProverPrivKey.PublicKey),
+		Prover: p.cfg.L1ProverSigner.Address(),
 		Proofs: proofs,
 	}
 
@@ -144,10 +147,12 @@ func (p *Prover) submitValidBlockProof(ctx context.Context, proofWithHeader *pro
 	if err != nil {
 		return fmt.Errorf("failed to send TaikoL1.proveBlock transaction: %w", err)
 	}
+	p.updateJobStatus(blockID, jobStore.StatusSubmitted, func(job *jobStore.Job) { job.TxHash = tx.Hash() })
 
 	if _, err := rpc.WaitReceipt(ctx, p.rpc.L1, tx); err != nil {
 		return fmt.Errorf("failed to wait till transaction executed: %w", err)
 	}
+	p.updateJobStatus(blockID, jobStore.StatusConfirmed, nil)
 
 	log.Info(
 		"✅ New valid block proved",
@@ -10,15 +10,16 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/metrics"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
+	feeStrategy "github.com/taikoxyz/taiko-client/pkg/fee_strategy"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	txListValidator "github.com/taikoxyz/taiko-client/pkg/tx_list_validator"
+	jobStore "github.com/taikoxyz/taiko-client/prover/job_store"
 	"github.com/taikoxyz/taiko-client/prover/producer"
 	"github.com/urfave/cli/v2"
 )
@@ -36,18 +37,22 @@ type Prover struct {
 	cfg *Config
 
 	// Clients
-	rpc *rpc.Client
+	rpc         *rpc.Client
+	feeStrategy *feeStrategy.FeeStrategy
 
 	// Contract configurations
-	txListValidator  *txListValidator.TxListValidator
-	anchorGasLimit   uint64
-	maxPendingBlocks uint64
-	zkProofsPerBlock uint64
+	txListValidator        *txListValidator.TxListValidator
+	anchorGasLimit         uint64
+	maxPendingBlocks       uint64
+	zkProofsPerBlock       uint64
+	maxProofsPerForkChoice uint64
 
 	// States
 	lastVerifiedHeader   *types.Header
 	lastVerifiedL1Height uint64
 	l1Current            uint64
+	jobStore             *jobStore.JobStore // Lifecycle of the proving jobs, survives restarts
+	selection            blockSelectionCache
 
 	// Subscriptions
 	blockProposedCh  chan *bindings.TaikoL1ClientBlockProposed
@@ -91,8 +96,9 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 	}); err != nil {
 		return err
 	}
+	p.feeStrategy = feeStrategy.New(p.rpc.L1, cfg.FeeStrategy)
 
-	proverAddress := crypto.PubkeyToAddress(p.cfg.L1ProverPrivKey.PublicKey)
+	proverAddress := p.cfg.L1ProverSigner.Address()
 	isWhitelisted, err := p.isWhitelisted(proverAddress)
 	if err != nil {
 		return fmt.Errorf("failed to check whether current prover %s is whitelisted: %w", proverAddress, err)
@@ -103,7 +109,7 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 	}
 
 	// Constants
-	zkProofsPerBlock, _, maxPendingBlocks, _, _, _,
+	zkProofsPerBlock, _, maxPendingBlocks, _, _, maxProofsPerForkChoice,
 		maxBlocksGasLimit, maxBlockNumTxs, _, maxTxlistBytes, minTxGasLimit,
 		anchorGasLimit, _, _, err := p.rpc.TaikoL1.GetConstants(nil)
 	if err != nil {
@@ -117,6 +123,7 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 		"maxBlockNumTxs", maxBlockNumTxs,
 		"maxTxlistBytes", maxTxlistBytes,
 		"maxPendingBlocks", maxPendingBlocks,
+		"maxProofsPerForkChoice", maxProofsPerForkChoice,
 		"anchorGasLimit", anchorGasLimit,
 	)
 
@@ -126,25 +133,33 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 		maxTxlistBytes.Uint64(),
 		minTxGasLimit.Uint64(),
 		p.rpc.L2ChainID,
+		p.cfg.CompressedTxLists,
 	)
 	p.zkProofsPerBlock = zkProofsPerBlock.Uint64()
 	p.maxPendingBlocks = maxPendingBlocks.Uint64()
+	p.maxProofsPerForkChoice = maxProofsPerForkChoice.Uint64()
 	p.anchorGasLimit = anchorGasLimit.Uint64()
 	p.blockProposedCh = make(chan *bindings.TaikoL1ClientBlockProposed, p.maxPendingBlocks)
 	p.blockVerifiedCh = make(chan *bindings.TaikoL1ClientBlockVerified, p.maxPendingBlocks)
 	p.proveValidProofCh = make(chan *producer.ProofWithHeader, p.maxPendingBlocks)
 	p.proveInvalidProofCh = make(chan *producer.ProofWithHeader, p.maxPendingBlocks)
 	p.proveNotify = make(chan struct{}, 1)
+	if p.jobStore, err = jobStore.New(cfg.JobStorePath); err != nil {
+		return err
+	}
 	if err := p.initL1Current(); err != nil {
 		return fmt.Errorf("initialize L1 current cursor error: %w", err)
 	}
 
+	var backend producer.ProofProducer
 	if cfg.Dummy {
-		p.proofProducer = new(producer.DummyProofProducer)
-	} else {
-		if p.proofProducer, err = producer.NewZkevmRpcdProducer(cfg.ZKEvmRpcdEndpoint); err != nil {
-			return err
-		}
+		backend = new(producer.DummyProofProducer)
+	} else if backend, err = producer.NewZkevmRpcdCompositeProducer(p.ctx, cfg.ZKEvmRpcdEndpoints); err != nil {
+		return err
+	}
+
+	if p.proofProducer, err = producer.NewWorkerPool(p.ctx, cfg.ProofConcurrency, backend); err != nil {
+		return err
 	}
 
 	return nil
@@ -152,6 +167,10 @@ func InitFromConfig(ctx context.Context, p *Prover, cfg *Config) (err error) {
 
 // Start starts the main loop of the L2 block prover.
 func (p *Prover) Start() error {
+	if err := p.resumeJobs(p.ctx); err != nil {
+		return fmt.Errorf("failed to resume proving jobs: %w", err)
+	}
+
 	p.wg.Add(1)
 	p.startSubscription()
 	go p.eventLoop()
@@ -182,12 +201,22 @@ func (p *Prover) eventLoop() {
 		case <-p.ctx.Done():
 			return
 		case proofWithHeader := <-p.proveValidProofCh:
+			if proofWithHeader.Err != nil {
+				p.failJob(proofWithHeader.BlockID, proofWithHeader.Err)
+				continue
+			}
 			if err := p.submitValidBlockProof(p.ctx, proofWithHeader); err != nil {
 				log.Error("Prove valid block error", "error", err)
+				p.failJob(proofWithHeader.BlockID, err)
 			}
 		case proofWithHeader := <-p.proveInvalidProofCh:
+			if proofWithHeader.Err != nil {
+				p.failJob(proofWithHeader.BlockID, proofWithHeader.Err)
+				continue
+			}
 			if err := p.submitInvalidBlockProof(p.ctx, proofWithHeader); err != nil {
 				log.Error("Prove invalid block error", "error", err)
+				p.failJob(proofWithHeader.BlockID, err)
 			}
 		case <-p.proveNotify:
 			if err := p.proveOp(); err != nil {
@@ -207,10 +236,18 @@ func (p *Prover) eventLoop() {
 func (p *Prover) Close() {
 	p.closeSubscription()
 	p.wg.Wait()
+
+	if closable, ok := p.proofProducer.(producer.ClosableProducer); ok {
+		closable.Close()
+	}
+
+	if err := p.jobStore.Close(); err != nil {
+		log.Error("Failed to close job store", "error", err)
+	}
 }
 
 // proveOp perfors a proving operation, find current unproven blocks, then
-// request generating proofs for them.
+// request generating proofs for the selected ones.
 func (p *Prover) proveOp() error {
 	isHalted, err := p.rpc.TaikoL1.IsHalted(nil)
 	if err != nil {
@@ -222,26 +259,56 @@ func (p *Prover) proveOp() error {
 		return nil
 	}
 
-	iter, err := eventIterator.NewBlockProposedIterator(p.ctx, &eventIterator.BlockProposedIteratorConfig{
-		Client:               p.rpc.L1,
-		TaikoL1:              p.rpc.TaikoL1,
-		StartHeight:          new(big.Int).SetUint64(p.l1Current),
-		OnBlockProposedEvent: p.onBlockProposed,
-	})
+	candidates, lastHeight, err := p.selectBlocks(p.ctx)
 	if err != nil {
 		return err
 	}
 
-	return iter.Iter()
+	var (
+		ended   bool
+		handled = make(map[*blockCandidate]bool)
+	)
+	for _, candidate := range candidates {
+		// Left unhandled, so the L1 cursor stays until the job is retried.
+		if p.jobBackingOff(candidate.event.Id) {
+			continue
+		}
+
+		err = p.onBlockProposed(p.ctx, candidate.event, candidate.proposeTx, func() { ended = true })
+		if err != nil || ended {
+			break
+		}
+		handled[candidate] = true
+	}
+
+	// Move the L1 cursor to the earliest block which has not been handled yet.
+	for _, candidate := range candidates {
+		if !handled[candidate] && candidate.event.Raw.BlockNumber < lastHeight {
+			lastHeight = candidate.event.Raw.BlockNumber
+		}
+	}
+	p.l1Current = lastHeight
+
+	return err
 }
 
-// onBlockProposed tries to prove that the newly proposed block is valid/invalid.
+// onBlockProposed tries to prove that the newly proposed block is valid/invalid, the given
+// TaikoL1.proposeBlock transaction is fetched if nil.
 func (p *Prover) onBlockProposed(
 	ctx context.Context,
 	event *bindings.TaikoL1ClientBlockProposed,
+	proposeBlockTx *types.Transaction,
 	end eventIterator.EndBlockProposeEventIterFunc,
 ) error {
-	if len(p.proveValidProofCh) > maxPendingProofs || len(p.proveInvalidProofCh) > maxPendingProofs {
+	var queued int
+	if queuedProducer, ok := p.proofProducer.(producer.QueuedProducer); ok {
+		queued = queuedProducer.Queued()
+	}
+
+	// Back off if too many proofs are waiting for a worker, or waiting to be submitted.
+	if queued >= maxPendingProofs ||
+		len(p.proveValidProofCh) > maxPendingProofs ||
+		len(p.proveInvalidProofCh) > maxPendingProofs {
 		end()
 		return nil
 	}
@@ -259,12 +326,31 @@ func (p *Prover) onBlockProposed(
 		return nil
 	}
 
-	// Check whether the transactions list is valid.
-	proposeBlockTx, err := p.rpc.L1.TransactionInBlock(ctx, event.Raw.BlockHash, event.Raw.TxIndex)
+	// Check whether the block's proof has been requested before, maybe before a restart.
+	job, err := p.jobStore.Get(event.Id.Uint64())
 	if err != nil {
 		return err
 	}
 
+	if job != nil {
+		if !job.Retryable() {
+			log.Info("Block proving job exists", "blockID", event.Id, "status", job.Status)
+			return nil
+		}
+
+		log.Info("Retry proving job", "blockID", event.Id, "status", job.Status)
+		if p.retryJob(job, end) {
+			return nil
+		}
+	}
+
+	// Check whether the transactions list is valid.
+	if proposeBlockTx == nil {
+		if proposeBlockTx, err = p.rpc.L1.TransactionInBlock(ctx, event.Raw.BlockHash, event.Raw.TxIndex); err != nil {
+			return err
+		}
+	}
+
 	hint, invalidTxIndex, err := p.txListValidator.ValidateTxList(event.Id, proposeBlockTx.Data())
 	if err != nil {
 		return err
@@ -288,6 +374,11 @@ func (p *Prover) onBlockVerified(ctx context.Context, event *bindings.TaikoL1Cli
 
 	metrics.ProverLatestVerifiedIDGauge.Update(event.Id.Int64())
 
+	if err := p.jobStore.Prune(event.Id.Uint64()); err != nil {
+		log.Error("Failed to prune proving jobs", "blockID", event.Id, "error", err)
+	}
+	p.selection.prune(event.Id.Uint64())
+
 	l2BlockHeader, err := p.rpc.L2.HeaderByHash(ctx, event.BlockHash)
 	if err != nil {
 		return fmt.Errorf("failed to find L2 block with hash %s: %w", common.BytesToHash(event.BlockHash[:]), err)
@@ -310,23 +401,16 @@ func (p *Prover) Name() string {
 	return "prover"
 }
 
-// getProveBlocksTxOpts creates a bind.TransactOpts instance using the given private key.
+// getProveBlocksTxOpts creates a bind.TransactOpts instance using the L1 prover signer, and the fees
+// suggested by the prover's fee strategy.
 // Used for creating TaikoL1.proveBlock and TaikoL1.proveBlockInvalid transactions.
-func (p *Prover) getProveBlocksTxOpts(ctx context.Context, cli *ethclient.Client) (*bind.TransactOpts, error) {
-	opts, err := bind.NewKeyedTransactorWithChainID(p.cfg.L1ProverPrivKey, p.rpc.L1ChainID)
-	if err != nil {
+func (p *Prover) getProveBlocksTxOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	opts := signer.NewTransactOpts(ctx, p.cfg.L1ProverSigner, p.rpc.L1ChainID)
+
+	if err := p.feeStrategy.Apply(ctx, opts); err != nil {
 		return nil, err
 	}
-	gasTipCap, err := cli.SuggestGasTipCap(ctx)
-	if err != nil {
-		if rpc.IsMaxPriorityFeePerGasNotFoundError(err) {
-			gasTipCap = rpc.FallbackGasTipCap
-		} else {
-			return nil, err
-		}
-	}
 
-	opts.GasTipCap = gasTipCap
 	opts.GasLimit = proveBlocksGasLimit
 
 	return opts, nil
@@ -338,17 +422,21 @@ func (p *Prover) initL1Current() error {
 		return err
 	}
 
-	if latestVerifiedID == 0 {
-		p.l1Current = 0
-		return nil
+	var l1Current uint64
+	if latestVerifiedID != 0 {
+		latestVerifiedHeaderL1Origin, err := p.rpc.L2.L1OriginByID(p.ctx, new(big.Int).SetUint64(latestVerifiedID))
+		if err != nil {
+			return err
+		}
+
+		l1Current = latestVerifiedHeaderL1Origin.L1BlockHeight.Uint64()
 	}
 
-	latestVerifiedHeaderL1Origin, err := p.rpc.L2.L1OriginByID(p.ctx, new(big.Int).SetUint64(latestVerifiedID))
-	if err != nil {
+	// Skip the BlockProposed events already handled before the last restart.
+	if p.l1Current, err = p.resumeL1Current(l1Current); err != nil {
 		return err
 	}
 
-	p.l1Current = latestVerifiedHeaderL1Origin.L1BlockHeight.Uint64()
 	return nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/driver"
 	"github.com/taikoxyz/taiko-client/pkg/jwt"
+	"github.com/taikoxyz/taiko-client/pkg/signer"
 	"github.com/taikoxyz/taiko-client/proposer"
 	"github.com/taikoxyz/taiko-client/testutils"
 )
@@ -34,12 +35,12 @@ func (s *ProverTestSuite) SetupTest() {
 
 	p := new(Prover)
 	s.Nil(InitFromConfig(context.Background(), p, (&Config{
-		L1Endpoint:      os.Getenv("L1_NODE_ENDPOINT"),
-		L2Endpoint:      os.Getenv("L2_NODE_ENDPOINT"),
-		TaikoL1Address:  common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
-		TaikoL2Address:  common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
-		L1ProverPrivKey: l1ProverPrivKey,
-		Dummy:           true,
+		L1Endpoint:     os.Getenv("L1_NODE_ENDPOINT"),
+		L2Endpoint:     os.Getenv("L2_NODE_ENDPOINT"),
+		TaikoL1Address: common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		TaikoL2Address: common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		L1ProverSigner: signer.NewPrivateKeySigner(l1ProverPrivKey),
+		Dummy:          true,
 	})))
 	s.p = p
 
@@ -55,13 +56,13 @@ func (s *ProverTestSuite) SetupTest() {
 
 	d := new(driver.Driver)
 	s.Nil(driver.InitFromConfig(context.Background(), d, &driver.Config{
-		L1Endpoint:                    os.Getenv("L1_NODE_ENDPOINT"),
-		L2Endpoint:                    os.Getenv("L2_NODE_ENDPOINT"),
-		L2EngineEndpoint:              os.Getenv("L2_NODE_ENGINE_ENDPOINT"),
-		TaikoL1Address:                common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
-		TaikoL2Address:                common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
-		ThrowawayBlocksBuilderPrivKey: throwawayBlocksBuilderPrivKey,
-		JwtSecret:                     string(jwtSecret),
+		L1Endpoint:                   os.Getenv("L1_NODE_ENDPOINT"),
+		L2Endpoint:                   os.Getenv("L2_NODE_ENDPOINT"),
+		L2EngineEndpoint:             os.Getenv("L2_NODE_ENGINE_ENDPOINT"),
+		TaikoL1Address:               common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		TaikoL2Address:               common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		ThrowawayBlocksBuilderSigner: signer.NewPrivateKeySigner(throwawayBlocksBuilderPrivKey),
+		JwtSecret:                    string(jwtSecret),
 	}))
 	s.d = d
 
@@ -72,13 +73,15 @@ func (s *ProverTestSuite) SetupTest() {
 	prop := new(proposer.Proposer)
 
 	s.Nil(proposer.InitFromConfig(context.Background(), prop, (&proposer.Config{
-		L1Endpoint:              os.Getenv("L1_NODE_ENDPOINT"),
-		L2Endpoint:              os.Getenv("L2_NODE_ENDPOINT"),
-		TaikoL1Address:          common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
-		TaikoL2Address:          common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
-		L1ProposerPrivKey:       l1ProposerPrivKey,
-		L2SuggestedFeeRecipient: common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")),
-		ProposeInterval:         1024 * time.Hour, // No need to periodically propose transactions list in unit tests
+		L1Endpoint:       os.Getenv("L1_NODE_ENDPOINT"),
+		L2Endpoint:       os.Getenv("L2_NODE_ENDPOINT"),
+		TaikoL1Address:   common.HexToAddress(os.Getenv("TAIKO_L1_ADDRESS")),
+		TaikoL2Address:   common.HexToAddress(os.Getenv("TAIKO_L2_ADDRESS")),
+		L1ProposerSigner: signer.NewPrivateKeySigner(l1ProposerPrivKey),
+		L2SuggestedFeeRecipients: []*proposer.FeeRecipient{
+			{Address: common.HexToAddress(os.Getenv("L2_SUGGESTED_FEE_RECIPIENT")), Weight: 1},
+		},
+		ProposeInterval: 1024 * time.Hour, // No need to periodically propose transactions list in unit tests
 	})))
 
 	s.proposer = prop
@@ -90,7 +93,7 @@ func (s *ProverTestSuite) TestName() {
 }
 
 func (s *ProverTestSuite) TestGetProveBlocksTxOpts() {
-	opts, err := s.p.getProveBlocksTxOpts(context.Background(), s.RpcClient.L1)
+	opts, err := s.p.getProveBlocksTxOpts(context.Background())
 	s.Nil(err)
 	s.Equal(proveBlocksGasLimit, opts.GasLimit)
 }
@@ -98,12 +101,12 @@ func (s *ProverTestSuite) TestGetProveBlocksTxOpts() {
 func (s *ProverTestSuite) TestOnBlockProposed() {
 	// Valid block
 	e := testutils.ProposeAndInsertValidBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer())
-	s.Nil(s.p.onBlockProposed(context.Background(), e, func() {}))
+	s.Nil(s.p.onBlockProposed(context.Background(), e, nil, func() {}))
 	s.Nil(s.p.submitValidBlockProof(context.Background(), <-s.p.proveValidProofCh))
 
 	// Invalid block
 	e = testutils.ProposeAndInsertThrowawayBlock(&s.ClientTestSuite, s.proposer, s.d.ChainSyncer())
-	s.Nil(s.p.onBlockProposed(context.Background(), e, func() {}))
+	s.Nil(s.p.onBlockProposed(context.Background(), e, nil, func() {}))
 	s.Nil(s.p.submitInvalidBlockProof(context.Background(), <-s.p.proveInvalidProofCh))
 }
 
@@ -112,7 +115,7 @@ func (s *ProverTestSuite) TestOnBlockProposedTxNotFound() {
 		s.p.onBlockProposed(context.Background(), &bindings.TaikoL1ClientBlockProposed{
 			Id:  common.Big2,
 			Raw: types.Log{BlockHash: common.Hash{}, TxIndex: 0},
-		}, func() {}),
+		}, nil, func() {}),
 		ethereum.NotFound.Error(),
 	)
 }
@@ -122,7 +125,7 @@ func (s *ProverTestSuite) TestOnBlockVerifiedEmptyBlockHash() {
 }
 
 func (s *ProverTestSuite) TestIsWhitelisted() {
-	isWhitelisted, err := s.p.isWhitelisted(crypto.PubkeyToAddress(s.p.cfg.L1ProverPrivKey.PublicKey))
+	isWhitelisted, err := s.p.isWhitelisted(s.p.cfg.L1ProverSigner.Address())
 	s.Nil(err)
 	s.True(isWhitelisted)
 }
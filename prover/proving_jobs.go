@@ -0,0 +1,251 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/taikoxyz/taiko-client/bindings"
+	jobStore "github.com/taikoxyz/taiko-client/prover/job_store"
+	"github.com/taikoxyz/taiko-client/prover/producer"
+)
+
+// requestProof records a new proving job of the given block, then requests its proof from the proof
+// producer, the job is marked failed if the request fails. The job is recorded before the request, so
+// the proof computation task can be recorded once submitted.
+func (p *Prover) requestProof(
+	event *bindings.TaikoL1ClientBlockProposed,
+	valid bool,
+	opts *producer.ProofRequestOptions,
+	header *types.Header,
+	resultCh chan *producer.ProofWithHeader,
+) error {
+	job := &jobStore.Job{
+		BlockID:      event.Id.Uint64(),
+		Valid:        valid,
+		L1Height:     event.Raw.BlockNumber,
+		Status:       jobStore.StatusRequested,
+		ProofOptions: opts,
+		Header:       header,
+	}
+
+	// Keep counting the failures of a retried job, to keep backing off.
+	if failedJob, err := p.jobStore.Get(job.BlockID); err == nil && failedJob != nil {
+		job.Attempts = failedJob.Attempts
+	}
+
+	if err := p.jobStore.Put(job); err != nil {
+		log.Error("Failed to save proving job", "blockID", event.Id, "error", err)
+	}
+
+	opts.OnTaskSubmitted = p.recordJobTask(event.Id)
+	if err := p.proofProducer.RequestProof(p.ctx, opts, event.Id, header, resultCh); err != nil {
+		p.failJob(event.Id, err)
+		return err
+	}
+
+	return nil
+}
+
+// recordJobTask returns a function which records the proof computation task of the given block's
+// proving job, so the task can be resumed after restarts.
+func (p *Prover) recordJobTask(blockID *big.Int) func(task string) {
+	return func(task string) {
+		if err := p.jobStore.Update(blockID.Uint64(), func(job *jobStore.Job) { job.Task = task }); err != nil {
+			log.Error("Failed to record proof computation task", "blockID", blockID, "error", err)
+		}
+	}
+}
+
+// updateJobStatus updates the status of the given block's proving job, the other fields
+// are updated by the given function if not nil.
+func (p *Prover) updateJobStatus(blockID *big.Int, status jobStore.Status, update func(job *jobStore.Job)) {
+	if err := p.jobStore.UpdateStatus(blockID.Uint64(), status, update); err != nil {
+		log.Error("Failed to update proving job", "blockID", blockID, "status", status, "error", err)
+	}
+}
+
+// failJob marks the given block's proving job failed, or expired if the proof was not generated in
+// time, and rewinds the L1 cursor to its BlockProposed event, so the job will be retried by the proving
+// operation after its backoff. The received proof, if any, is kept to be submitted again.
+func (p *Prover) failJob(blockID *big.Int, failure error) {
+	status := jobStore.StatusFailed
+	if errors.Is(failure, context.DeadlineExceeded) {
+		status = jobStore.StatusExpired
+	}
+
+	var (
+		backoff  time.Duration
+		attempts uint64
+		l1Height uint64
+	)
+	if err := p.jobStore.Update(blockID.Uint64(), func(job *jobStore.Job) {
+		backoff, attempts, l1Height = job.Fail(status), job.Attempts, job.L1Height
+	}); err != nil {
+		log.Error("Failed to update failed proving job", "blockID", blockID, "error", err)
+		return
+	}
+
+	log.Error(
+		"Proving job failed, retry later",
+		"blockID", blockID,
+		"status", status,
+		"attempts", attempts,
+		"backoff", backoff,
+		"error", failure,
+	)
+
+	if l1Height < p.l1Current {
+		p.l1Current = l1Height
+	}
+
+	// Request a proving operation once the backoff is over.
+	time.AfterFunc(backoff, func() {
+		select {
+		case p.proveNotify <- struct{}{}:
+		default:
+		}
+	})
+}
+
+// jobBackingOff returns whether the given block's proving job has failed and is waiting for its retry.
+func (p *Prover) jobBackingOff(blockID *big.Int) bool {
+	job, err := p.jobStore.Get(blockID.Uint64())
+	if err != nil || job == nil {
+		return false
+	}
+
+	return job.BackingOff()
+}
+
+// retryJob retries the given failed or expired proving job, the received proof is submitted again,
+// otherwise it returns false, and the proof should be requested again.
+func (p *Prover) retryJob(job *jobStore.Job, end func()) bool {
+	proofWithHeader := job.ProofWithHeader()
+	if proofWithHeader == nil {
+		return false
+	}
+
+	resultCh := p.proveValidProofCh
+	if !job.Valid {
+		resultCh = p.proveInvalidProofCh
+	}
+
+	// The event loop is busy submitting the other proofs, try again in the next proving operation.
+	select {
+	case resultCh <- proofWithHeader:
+		p.updateJobStatus(proofWithHeader.BlockID, jobStore.StatusProofReceived, nil)
+	default:
+		end()
+	}
+
+	return true
+}
+
+// resumeL1Current returns the L1 height to resume fetching the BlockProposed events from, which
+// is the latest L1 height of the stored proving jobs if it's higher than the given one, unless
+// there are failed or expired jobs to retry, then it's the earliest L1 height of them.
+func (p *Prover) resumeL1Current(l1Current uint64) (uint64, error) {
+	jobs, err := p.jobStore.Jobs()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		if job.L1Height > l1Current {
+			l1Current = job.L1Height
+		}
+	}
+
+	for _, job := range jobs {
+		if job.Retryable() && job.L1Height < l1Current {
+			l1Current = job.L1Height
+		}
+	}
+
+	return l1Current, nil
+}
+
+// resumeJobs resumes the proving jobs stored before the last restart in background. The received
+// proofs are submitted again, unless their submitted transactions have been executed, so they are
+// never requested from the proof producer again. The proofs still being generated are resumed by polling
+// their submitted proof computation tasks, or requested again if not submitted yet, since the in-flight
+// requests are lost with the process. The failed and expired jobs are retried by the proving operations.
+func (p *Prover) resumeJobs(ctx context.Context) error {
+	jobs, err := p.jobStore.Jobs()
+	if err != nil {
+		return err
+	}
+
+	var resumed []*jobStore.Job
+	for _, job := range jobs {
+		if job.Status == jobStore.StatusConfirmed || job.Retryable() {
+			continue
+		}
+
+		if job.Status == jobStore.StatusSubmitted {
+			receipt, err := p.rpc.L1.TransactionReceipt(ctx, job.TxHash)
+			if err == nil && receipt.Status == types.ReceiptStatusSuccessful {
+				p.updateJobStatus(new(big.Int).SetUint64(job.BlockID), jobStore.StatusConfirmed, nil)
+				continue
+			}
+		}
+
+		resumed = append(resumed, job)
+	}
+
+	if len(resumed) == 0 {
+		return nil
+	}
+
+	log.Info("Resume proving jobs", "count", len(resumed), "from", resumed[0].BlockID)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		for _, job := range resumed {
+			resultCh := p.proveValidProofCh
+			if !job.Valid {
+				resultCh = p.proveInvalidProofCh
+			}
+
+			if job.Status == jobStore.StatusRequested {
+				var (
+					blockID = new(big.Int).SetUint64(job.BlockID)
+					err     error
+				)
+				job.ProofOptions.OnTaskSubmitted = p.recordJobTask(blockID)
+
+				if resumable, ok := p.proofProducer.(producer.ResumableProducer); ok && job.Task != "" {
+					err = resumable.ResumeProof(ctx, job.Task, job.ProofOptions, blockID, job.Header, resultCh)
+				} else {
+					err = p.proofProducer.RequestProof(ctx, job.ProofOptions, blockID, job.Header, resultCh)
+				}
+				if err == nil {
+					continue
+				}
+
+				// Handled by the event loop like the other proof failures.
+				log.Error("Failed to resume proving job", "blockID", job.BlockID, "error", err)
+				select {
+				case resultCh <- &producer.ProofWithHeader{BlockID: blockID, Header: job.Header, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+
+			select {
+			case resultCh <- job.ProofWithHeader():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}